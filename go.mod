@@ -6,4 +6,5 @@ require (
 	github.com/cybergarage/go-cbor v1.3.2
 	github.com/cybergarage/go-pict v1.0.2
 	github.com/cybergarage/go-safecast v1.3.5
+	golang.org/x/text v0.34.0
 )
@@ -0,0 +1,104 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package document
+
+import (
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Collation represents how string key elements are compared. The key elements of a non-binary
+// collation are replaced with their collation keys, which are also strings, so that the encoded
+// keys sort and compare equal by the collation, and decode back as the collation keys.
+type Collation uint8
+
+const (
+	// BinaryCollation compares strings by their raw UTF-8 bytes.
+	BinaryCollation Collation = 0x00
+	// CaseFoldedCollation compares strings by their Unicode full case folding.
+	CaseFoldedCollation Collation = 0x01
+	// NFCCollation compares strings by their Unicode canonical composition (NFC).
+	NFCCollation Collation = 0x02
+	// SimpleCollation compares strings case-insensitively and accent-insensitively without any locale,
+	// like the general collations of SQL databases, by the case-folded base characters in NFC.
+	SimpleCollation Collation = 0x03
+)
+
+func isCollation(v uint8) bool {
+	switch Collation(v) {
+	case BinaryCollation, CaseFoldedCollation, NFCCollation, SimpleCollation:
+		return true
+	}
+	return false
+}
+
+// String returns the string representation of the collation.
+func (c Collation) String() string {
+	switch c {
+	case BinaryCollation:
+		return "binary"
+	case CaseFoldedCollation:
+		return "casefold"
+	case NFCCollation:
+		return "nfc"
+	case SimpleCollation:
+		return "simple"
+	default:
+		return ""
+	}
+}
+
+// Key returns the collation key of the specified string, which sorts bytewise in the collation order.
+// The collation key of a collation key is itself.
+func (c Collation) Key(s string) string {
+	switch c {
+	case CaseFoldedCollation:
+		return cases.Fold().String(s)
+	case NFCCollation:
+		return norm.NFC.String(s)
+	case SimpleCollation:
+		// Remove the combining marks from the canonical decomposition, e.g. "é" as "e" and U+0301
+		base := []rune{}
+		for _, r := range norm.NFD.String(s) {
+			if unicode.Is(unicode.Mn, r) {
+				continue
+			}
+			base = append(base, r)
+		}
+		return norm.NFC.String(cases.Fold().String(string(base)))
+	default:
+		return s
+	}
+}
+
+// Collate returns the collation key of the specified key element if it is a string or a byte slice,
+// keeping the type, otherwise returns the key element as it is.
+func (c Collation) Collate(v any) any {
+	if c == BinaryCollation {
+		return v
+	}
+	switch v := v.(type) {
+	case string:
+		return c.Key(v)
+	case []byte:
+		return []byte(c.Key(string(v)))
+	case OrderedValue:
+		v.Value = c.Collate(v.Value)
+		return v
+	}
+	return v
+}
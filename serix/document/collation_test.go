@@ -0,0 +1,58 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package document
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCollation(t *testing.T) {
+	cases := []struct {
+		collation Collation
+		s         string
+		key       string
+	}{
+		{BinaryCollation, "Apple", "Apple"},
+		{CaseFoldedCollation, "Apple", "apple"},
+		{CaseFoldedCollation, "STRASSE", "strasse"},
+		{CaseFoldedCollation, "Straße", "strasse"},
+		{CaseFoldedCollation, "ΣΊΣΥΦΟΣ", "σίσυφοσ"},
+		{NFCCollation, "é", "é"},
+		{NFCCollation, "Apple", "Apple"},
+		{SimpleCollation, "Résumé", "resume"},
+		{SimpleCollation, "RÉSUMÉ", "resume"},
+		{SimpleCollation, "Ångström", "angstrom"},
+	}
+	for _, c := range cases {
+		key := c.collation.Key(c.s)
+		if key != c.key {
+			t.Errorf("%s: %q != %q", c.collation, key, c.key)
+		}
+		if c.collation.Key(key) != key {
+			t.Errorf("%s: %q is not a collation key", c.collation, key)
+		}
+	}
+
+	if v := CaseFoldedCollation.Collate([]byte("Apple")); !bytes.Equal(v.([]byte), []byte("apple")) {
+		t.Errorf("%v != %v", v, []byte("apple"))
+	}
+	if v := CaseFoldedCollation.Collate(Desc("Apple")); v.(OrderedValue).Value != "apple" {
+		t.Errorf("%v != %v", v, Desc("apple"))
+	}
+	if v := CaseFoldedCollation.Collate(int64(1)); v != int64(1) {
+		t.Errorf("%v != %v", v, int64(1))
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package document
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// DecimalMaxScale is the maximum absolute scale of the decimals parsed from strings, which bounds the zeros padded by
+// their representations, e.g. 1e4096 and 1e-4096 are the largest and smallest parsable powers of ten.
+const DecimalMaxScale = 4096
+
+// Decimal represents an arbitrary-precision decimal number, unscaled * 10^-scale.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+// NewDecimal returns a new decimal from the specified unscaled value and scale.
+func NewDecimal(unscaled *big.Int, scale int32) Decimal {
+	return Decimal{
+		unscaled: new(big.Int).Set(unscaled),
+		scale:    scale,
+	}
+}
+
+// NewDecimalFromString returns a new decimal from the specified string such as "-123.45" or "1.5e3".
+// The absolute scale of the decimal must not exceed DecimalMaxScale.
+func NewDecimalFromString(s string) (Decimal, error) {
+	mantissa := s
+	exp := int64(0)
+	if n := strings.IndexAny(s, "eE"); 0 <= n {
+		mantissa = s[:n]
+		e, ok := new(big.Int).SetString(s[n+1:], 10)
+		if !ok || !e.IsInt64() {
+			return Decimal{}, newErrDecimalInvalid(s)
+		}
+		exp = e.Int64()
+	}
+	scale := int64(0)
+	if n := strings.IndexByte(mantissa, '.'); 0 <= n {
+		scale = int64(len(mantissa) - n - 1)
+		mantissa = mantissa[:n] + mantissa[n+1:]
+	}
+	if mantissa == "" || mantissa == "+" || mantissa == "-" || strings.ContainsAny(mantissa[1:], "+-") {
+		return Decimal{}, newErrDecimalInvalid(s)
+	}
+	unscaled, ok := new(big.Int).SetString(mantissa, 10)
+	if !ok {
+		return Decimal{}, newErrDecimalInvalid(s)
+	}
+	scale -= exp
+	if scale < -DecimalMaxScale || DecimalMaxScale < scale {
+		return Decimal{}, newErrDecimalInvalid(s)
+	}
+	return Decimal{
+		unscaled: unscaled,
+		scale:    int32(scale),
+	}, nil
+}
+
+// NewDecimalFromBigFloat returns a new decimal with the exact value of the specified finite big float.
+func NewDecimalFromBigFloat(v *big.Float) (Decimal, error) {
+	if v == nil || v.IsInf() {
+		return Decimal{}, newErrDecimalInvalid(fmt.Sprintf("%v", v))
+	}
+	// Finite binary floats have an exact decimal expansion: num / 2^k = num * 5^k / 10^k
+	rat, _ := v.Rat(nil)
+	k := rat.Denom().BitLen() - 1
+	pow := new(big.Int).Exp(big.NewInt(5), big.NewInt(int64(k)), nil)
+	return Decimal{
+		unscaled: new(big.Int).Mul(rat.Num(), pow),
+		scale:    int32(k),
+	}, nil
+}
+
+// Unscaled returns the unscaled value of the decimal.
+func (d Decimal) Unscaled() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(d.unscaled)
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of the decimal.
+func (d Decimal) Sign() int {
+	if d.unscaled == nil {
+		return 0
+	}
+	return d.unscaled.Sign()
+}
+
+// Rat returns the exact rational value of the decimal.
+func (d Decimal) Rat() *big.Rat {
+	if d.scale < 0 {
+		pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(-int64(d.scale)), nil)
+		return new(big.Rat).SetInt(new(big.Int).Mul(d.Unscaled(), pow))
+	}
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.scale)), nil)
+	return new(big.Rat).SetFrac(d.Unscaled(), pow)
+}
+
+// Cmp compares the decimal with another decimal by value regardless of their scales.
+func (d Decimal) Cmp(other Decimal) int {
+	return d.Rat().Cmp(other.Rat())
+}
+
+// String returns the string representation of the decimal.
+func (d Decimal) String() string {
+	unscaled := d.Unscaled()
+	digits := new(big.Int).Abs(unscaled).String()
+	sign := ""
+	if unscaled.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale <= 0 {
+		return sign + digits + strings.Repeat("0", -int(d.scale))
+	}
+	scale := int(d.scale)
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
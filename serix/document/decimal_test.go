@@ -0,0 +1,104 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package document
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestDecimal(t *testing.T) {
+	t.Run("string", func(t *testing.T) {
+		cases := []struct {
+			from     string
+			expected string
+		}{
+			{"0", "0"},
+			{"123", "123"},
+			{"-123.45", "-123.45"},
+			{"0.001", "0.001"},
+			{"-0.5", "-0.5"},
+			{"1.50", "1.50"},
+			{"1.5e3", "1500"},
+			{"1.5e-3", "0.0015"},
+			{"+7", "7"},
+			{"1e4096", "1" + strings.Repeat("0", DecimalMaxScale)},
+			{"1e-4096", "0." + strings.Repeat("0", DecimalMaxScale-1) + "1"},
+		}
+		for _, c := range cases {
+			d, err := NewDecimalFromString(c.from)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.String() != c.expected {
+				t.Errorf("%s != %s", d.String(), c.expected)
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, s := range []string{"", "-", "1.2.3", "abc", "1e", "1-2", "1e2000000000", "1e-99999", "1e4097", "0." + strings.Repeat("0", DecimalMaxScale) + "1"} {
+			if _, err := NewDecimalFromString(s); err == nil {
+				t.Errorf("expected error for %q", s)
+			}
+		}
+	})
+
+	t.Run("bigfloat", func(t *testing.T) {
+		cases := []struct {
+			from     *big.Float
+			expected string
+		}{
+			{big.NewFloat(0), "0"},
+			{big.NewFloat(1.5), "1.5"},
+			{big.NewFloat(-0.125), "-0.125"},
+			{big.NewFloat(0.1), "0.1000000000000000055511151231257827021181583404541015625"},
+			{new(big.Float).SetInt64(1 << 40), "1099511627776"},
+		}
+		for _, c := range cases {
+			d, err := NewDecimalFromBigFloat(c.from)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.String() != c.expected {
+				t.Errorf("%s != %s", d.String(), c.expected)
+			}
+		}
+		for _, v := range []*big.Float{nil, new(big.Float).SetInf(false), new(big.Float).SetInf(true)} {
+			if _, err := NewDecimalFromBigFloat(v); err == nil {
+				t.Errorf("expected error for %v", v)
+			}
+		}
+	})
+
+	t.Run("compare", func(t *testing.T) {
+		a := NewDecimal(big.NewInt(150), 2)
+		b := NewDecimal(big.NewInt(15), 1)
+		c := NewDecimal(big.NewInt(151), 2)
+		if a.Cmp(b) != 0 {
+			t.Errorf("%s != %s", a, b)
+		}
+		if a.Cmp(c) >= 0 {
+			t.Errorf("%s >= %s", a, c)
+		}
+		if cmp, err := NewKeyWith(a).Compare(NewKeyWith(1.5)); err != nil || cmp != 0 {
+			t.Errorf("expected %s == 1.5, cmp=%d err=%v", a, cmp, err)
+		}
+		if cmp, err := NewKeyWith(big.NewInt(2)).Compare(NewKeyWith(c)); err != nil || cmp <= 0 {
+			t.Errorf("expected 2 > %s, cmp=%d err=%v", c, cmp, err)
+		}
+	})
+}
@@ -23,6 +23,7 @@ var (
 	ErrNotSupported = errors.New("not supported")
 	ErrInvalid      = errors.New("invalid")
 	ErrNotExist     = errors.New("not exist")
+	ErrExist        = errors.New("already exist")
 )
 
 func newErrElementMapNotExist() error {
@@ -78,3 +79,24 @@ func NewErrPrimaryIndexNotExist() error {
 func NewErrObjectNotExist(key Key) error {
 	return fmt.Errorf("object (%s) is %w ", key, ErrNotExist)
 }
+
+// NewErrObjectExist returns a new error that the object is already exist.
+func NewErrObjectExist(key Key) error {
+	return fmt.Errorf("object (%s) is %w", key, ErrExist)
+}
+
+func newErrIndexKeyInvalid(idx Index, key Key) error {
+	return fmt.Errorf("key (%s) for index (%s) is %w", key.String(), idx.Name(), ErrInvalid)
+}
+
+func newErrDecimalInvalid(s string) error {
+	return fmt.Errorf("decimal (%s) is %w", s, ErrInvalid)
+}
+
+func newErrIndexKeyElementInvalid(idx Index, elem Element, v any) error {
+	return fmt.Errorf("key element (%T:%v) for index element (%s:%s) of index (%s) is %w", v, v, elem.Name(), elem.Type().String(), idx.Name(), ErrInvalid)
+}
+
+func newErrKeyFormatInvalid(str string, pos int, msg string) error {
+	return fmt.Errorf("key format (%s) at %d is %w: %s", str, pos, ErrInvalid, msg)
+}
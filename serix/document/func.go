@@ -14,6 +14,10 @@
 
 package document
 
+import (
+	"github.com/cybergarage/go-safecast/safecast"
+)
+
 func schemaMapFrom(obj any) (map[uint8]any, bool) {
 	smap, ok := obj.(map[uint8]any)
 	if ok {
@@ -76,3 +80,38 @@ func indexesFrom(obj any) ([]string, bool) {
 	}
 	return idxes, true
 }
+
+func ordersFrom(obj any) ([]uint8, bool) {
+	switch v := obj.(type) {
+	case []uint8:
+		orders := make([]uint8, len(v))
+		copy(orders, v)
+		return orders, true
+	case []any:
+		orders := []uint8{}
+		for _, o := range v {
+			var order uint8
+			if err := safecast.ToUint8(o, &order); err != nil {
+				return nil, false
+			}
+			orders = append(orders, order)
+		}
+		return orders, true
+	}
+	return nil, false
+}
+
+// validOrdersFrom returns the byte values of the index element orders, null orders or collations,
+// or false if any value is not valid.
+func validOrdersFrom(obj any, valid func(uint8) bool) ([]uint8, bool) {
+	orders, ok := ordersFrom(obj)
+	if !ok {
+		return nil, false
+	}
+	for _, order := range orders {
+		if !valid(order) {
+			return nil, false
+		}
+	}
+	return orders, true
+}
@@ -34,6 +34,18 @@ type Index interface {
 	SetType(t IndexType) Index
 	// AddElement returns the schema elements.
 	AddElement(elem Element) Index
+	// SetElementOrder sets the specified sort order to the index element.
+	SetElementOrder(name string, order Order) Index
+	// ElementOrder returns the sort order of the specified index element.
+	ElementOrder(name string) Order
+	// SetElementNullOrder sets the specified null order to the index element.
+	SetElementNullOrder(name string, nulls NullOrder) Index
+	// ElementNullOrder returns the null order of the specified index element.
+	ElementNullOrder(name string) NullOrder
+	// SetElementCollation sets the specified string collation to the index element.
+	SetElementCollation(name string, c Collation) Index
+	// ElementCollation returns the string collation of the specified index element.
+	ElementCollation(name string) Collation
 	// Data returns the raw representation data in memory.
 	Data() any
 }
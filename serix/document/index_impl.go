@@ -14,21 +14,32 @@
 
 package document
 
+import "strings"
+
 // Schema format (version 1)
 //
 // map[uint8]any
 // 1: name - string
 // 2: type - uint8
 // 3: elements - []string
+// 4: orders - []uint8 (element order)
+// 5: nulls - []uint8 (element null order)
+// 6: collations - []uint8 (element collation)
 
 const (
-	indexNameIdx     = 1
-	indexTypeIdx     = 2
-	indexElementsIdx = 3
+	indexNameIdx       = 1
+	indexTypeIdx       = 2
+	indexElementsIdx   = 3
+	indexOrdersIdx     = 4
+	indexNullsIdx      = 5
+	indexCollationsIdx = 6
 )
 
 type indexMap = map[uint8]any
 type indexElements = []string
+type indexOrders = []uint8
+type indexNullOrders = []uint8
+type indexCollations = []uint8
 
 type index struct {
 	data     map[uint8]any
@@ -42,6 +53,9 @@ func NewIndex() Index {
 		elements: []Element{},
 	}
 	idx.data[indexElementsIdx] = indexElements{}
+	idx.data[indexOrdersIdx] = indexOrders{}
+	idx.data[indexNullsIdx] = indexNullOrders{}
+	idx.data[indexCollationsIdx] = indexCollations{}
 	return idx
 }
 
@@ -70,6 +84,29 @@ func newIndexWith(s *schema, obj any) (Index, error) {
 		i.elements = append(i.elements, em)
 	}
 
+	// Normalizes the element orders, null orders and collations, which must not fall back to the defaults
+	// silently, because the keys of the index would be encoded in another order
+
+	slots := []struct {
+		idx   uint8
+		valid func(uint8) bool
+	}{
+		{indexOrdersIdx, isOrder},
+		{indexNullsIdx, isNullOrder},
+		{indexCollationsIdx, isCollation},
+	}
+	for _, slot := range slots {
+		v, ok := im[slot.idx]
+		if !ok || v == nil {
+			continue
+		}
+		orders, ok := validOrdersFrom(v, slot.valid)
+		if !ok {
+			return nil, newErrIndexInvalid(v)
+		}
+		im[slot.idx] = orders
+	}
+
 	return i, nil
 }
 
@@ -133,12 +170,142 @@ func (idx *index) AddElement(elem Element) Index {
 	if !ok {
 		return idx
 	}
+	orders := idx.indexOrders()
+	nulls := idx.indexNullOrders()
+	collations := idx.indexCollations()
 	idx.data[indexElementsIdx] = append(es, elem.Name())
+	idx.data[indexOrdersIdx] = append(orders, uint8(Ascending))
+	idx.data[indexNullsIdx] = append(nulls, uint8(NullsDefault))
+	idx.data[indexCollationsIdx] = append(collations, uint8(BinaryCollation))
 	// Add element to cache
 	idx.elements = append(idx.elements, elem)
 	return idx
 }
 
+func (idx *index) indexOrders() indexOrders {
+	es, ok := idx.indexElements()
+	if !ok {
+		return indexOrders{}
+	}
+	orders, ok := ordersFrom(idx.data[indexOrdersIdx])
+	if !ok {
+		orders = indexOrders{}
+	}
+	// Indexes created without orders are sorted in ascending order
+	for len(orders) < len(es) {
+		orders = append(orders, uint8(Ascending))
+	}
+	return orders[:len(es)]
+}
+
+func (idx *index) indexNullOrders() indexNullOrders {
+	es, ok := idx.indexElements()
+	if !ok {
+		return indexNullOrders{}
+	}
+	nulls, ok := ordersFrom(idx.data[indexNullsIdx])
+	if !ok {
+		nulls = indexNullOrders{}
+	}
+	// Indexes created without null orders sort null elements as the lowest value
+	for len(nulls) < len(es) {
+		nulls = append(nulls, uint8(NullsDefault))
+	}
+	return nulls[:len(es)]
+}
+
+func (idx *index) indexCollations() indexCollations {
+	es, ok := idx.indexElements()
+	if !ok {
+		return indexCollations{}
+	}
+	collations, ok := ordersFrom(idx.data[indexCollationsIdx])
+	if !ok {
+		collations = indexCollations{}
+	}
+	// Indexes created without collations compare strings by their bytes
+	for len(collations) < len(es) {
+		collations = append(collations, uint8(BinaryCollation))
+	}
+	return collations[:len(es)]
+}
+
+func (idx *index) elementPosition(name string) (int, bool) {
+	es, ok := idx.indexElements()
+	if !ok {
+		return 0, false
+	}
+	for n, e := range es {
+		if strings.EqualFold(e, name) {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// SetElementOrder sets the specified sort order to the index element.
+func (idx *index) SetElementOrder(name string, order Order) Index {
+	n, ok := idx.elementPosition(name)
+	if !ok {
+		return idx
+	}
+	orders := idx.indexOrders()
+	orders[n] = uint8(order)
+	idx.data[indexOrdersIdx] = orders
+	return idx
+}
+
+// ElementOrder returns the sort order of the specified index element.
+func (idx *index) ElementOrder(name string) Order {
+	n, ok := idx.elementPosition(name)
+	if !ok {
+		return Ascending
+	}
+	return Order(idx.indexOrders()[n])
+}
+
+// SetElementNullOrder sets the specified null order to the index element.
+func (idx *index) SetElementNullOrder(name string, nulls NullOrder) Index {
+	n, ok := idx.elementPosition(name)
+	if !ok {
+		return idx
+	}
+	orders := idx.indexNullOrders()
+	orders[n] = uint8(nulls)
+	idx.data[indexNullsIdx] = orders
+	return idx
+}
+
+// ElementNullOrder returns the null order of the specified index element.
+func (idx *index) ElementNullOrder(name string) NullOrder {
+	n, ok := idx.elementPosition(name)
+	if !ok {
+		return NullsDefault
+	}
+	return NullOrder(idx.indexNullOrders()[n])
+}
+
+// SetElementCollation sets the specified string collation to the index element.
+func (idx *index) SetElementCollation(name string, c Collation) Index {
+	n, ok := idx.elementPosition(name)
+	if !ok {
+		return idx
+	}
+	collations := idx.indexCollations()
+	collations[n] = uint8(c)
+	idx.data[indexCollationsIdx] = collations
+	return idx
+}
+
+// ElementCollation returns the string collation of the specified index element.
+func (idx *index) ElementCollation(name string) Collation {
+	n, ok := idx.elementPosition(name)
+	if !ok {
+		return BinaryCollation
+	}
+	return Collation(idx.indexCollations()[n])
+}
+
 // Elements returns the schema elements.
 func (idx *index) Elements() []Element {
 	return idx.elements
@@ -0,0 +1,163 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package document
+
+import (
+	"errors"
+	"time"
+)
+
+// NewIndexKeyWith returns a new key from the specified index element values.
+// The values are wrapped with the sort orders and the null orders of the index elements, string values are
+// replaced with the collation keys of the index elements, and fewer values than the index elements are allowed
+// to build a prefix key for range scans.
+func NewIndexKeyWith(idx Index, elems ...any) (Key, error) {
+	idxElems := idx.Elements()
+	if len(idxElems) < len(elems) {
+		return nil, newErrIndexKeyInvalid(idx, NewKeyWith(elems...))
+	}
+	key := NewKey()
+	for n, elem := range elems {
+		key = append(key, newIndexKeyElement(idx, idxElems[n], elem))
+	}
+	return key, nil
+}
+
+// NewIndexKeyFrom returns a new key whose elements are cast to the types of the index elements.
+// The key is typically decoded by a key coder which does not keep the Go types, such as int64 for
+// Int8Type, and returns an error if an element disagrees with the type, the sort order or the collation of the index element.
+// Null elements are kept as they are, and fewer elements than the index elements are allowed for prefix keys.
+func NewIndexKeyFrom(idx Index, key Key) (Key, error) {
+	idxElems := idx.Elements()
+	if len(idxElems) < len(key) {
+		return nil, newErrIndexKeyInvalid(idx, key)
+	}
+	typedKey := NewKey()
+	for n, elem := range key {
+		idxElem := idxElems[n]
+		order := idx.ElementOrder(idxElem.Name())
+		v := elem
+		ov, ok := elem.(OrderedValue)
+		if ok {
+			v = ov.Value
+		} else {
+			ov = Asc(v)
+		}
+		if ov.Order != order {
+			return nil, newErrIndexKeyElementInvalid(idx, idxElem, elem)
+		}
+		// Null elements must be placed by the null order of the index element
+		nulls := NewOrderedValue(nil, order).WithNulls(idx.ElementNullOrder(idxElem.Name()))
+		if v == nil && ov.IsNullsLast() != nulls.IsNullsLast() {
+			return nil, newErrIndexKeyElementInvalid(idx, idxElem, elem)
+		}
+		if v != nil {
+			if !isValueForType(idxElem.Type(), v) {
+				return nil, newErrIndexKeyElementInvalid(idx, idxElem, elem)
+			}
+			tv, err := NewValueForType(idxElem.Type(), keyElementValueForType(v))
+			if err != nil {
+				return nil, errors.Join(newErrIndexKeyElementInvalid(idx, idxElem, elem), err)
+			}
+			// Strings of non-binary collations must be collation keys
+			if s, ok := tv.(string); ok && idx.ElementCollation(idxElem.Name()).Key(s) != s {
+				return nil, newErrIndexKeyElementInvalid(idx, idxElem, elem)
+			}
+			v = tv
+		}
+		typedKey = append(typedKey, newIndexKeyElement(idx, idxElem, v))
+	}
+	return typedKey, nil
+}
+
+// newIndexKeyElement returns the collated key element wrapped with the sort order and the null order of the index
+// element, or the collated value as it is if the index element is sorted in the default ascending order.
+func newIndexKeyElement(idx Index, idxElem Element, v any) any {
+	v = idx.ElementCollation(idxElem.Name()).Collate(v)
+	order := idx.ElementOrder(idxElem.Name())
+	nulls := idx.ElementNullOrder(idxElem.Name())
+	if order == Ascending && nulls == NullsDefault {
+		return v
+	}
+	return NewOrderedValue(v, order).WithNulls(nulls)
+}
+
+// DecodeIndexKey decodes the specified bytes with the decoder, and returns the key whose elements
+// are cast to the types of the index elements by NewIndexKeyFrom.
+func DecodeIndexKey(decoder KeyDecoder, idx Index, b []byte) (Key, error) {
+	key, err := decoder.DecodeKey(b)
+	if err != nil {
+		return nil, err
+	}
+	return NewIndexKeyFrom(idx, key)
+}
+
+// isValueForType returns true if the specified decoded key element can represent a value of the element type.
+// Floats accept integers because integral floats may be decoded as integers, and booleans accept integers
+// because some SQL style coders encode booleans as integers.
+func isValueForType(et ElementType, v any) bool {
+	switch et { //nolint:exhaustive
+	case Int8Type, Int16Type, Int32Type, Int64Type:
+		return isIntegerValue(v)
+	case Float32Type, Float64Type:
+		switch v.(type) {
+		case float32, float64:
+			return true
+		}
+		return isIntegerValue(v)
+	case StringType:
+		switch v.(type) {
+		case string, []byte:
+			return true
+		}
+		return false
+	case BinaryType:
+		switch v.(type) {
+		case string:
+			return true
+		}
+		_, ok := keyElementBytes(v)
+		return ok
+	case BoolType:
+		if _, ok := v.(bool); ok {
+			return true
+		}
+		return isIntegerValue(v)
+	case DatetimeType:
+		_, ok := v.(time.Time)
+		return ok
+	}
+	return true
+}
+
+func isIntegerValue(v any) bool {
+	switch v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return true
+	}
+	return false
+}
+
+// keyElementValueForType returns the value which NewValueForType can cast, e.g. byte arrays as byte slices.
+func keyElementValueForType(v any) any {
+	switch v.(type) {
+	case string, []byte:
+		return v
+	}
+	if b, ok := keyElementBytes(v); ok {
+		return b
+	}
+	return v
+}
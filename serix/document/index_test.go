@@ -0,0 +1,221 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package document
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIndexElementOrder(t *testing.T) {
+	s1 := NewSchema()
+	e1 := NewElement().SetName("a").SetType(Int64Type)
+	e2 := NewElement().SetName("b").SetType(StringType)
+	s1.AddElement(e1)
+	s1.AddElement(e2)
+
+	idx := NewIndex().SetName("idx").SetType(SecondaryIndex)
+	idx.AddElement(e1)
+	idx.AddElement(e2)
+	idx.SetElementOrder("b", Descending)
+	s1.AddIndex(idx)
+
+	s2, err := NewSchemaWith(s1.Data())
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx2, err := s2.FindIndex("idx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order := idx2.ElementOrder("a"); order != Ascending {
+		t.Errorf("%v != %v", order, Ascending)
+	}
+	if order := idx2.ElementOrder("b"); order != Descending {
+		t.Errorf("%v != %v", order, Descending)
+	}
+
+	key, err := NewIndexKeyWith(idx2, 1, "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := key[1].(OrderedValue); !ok {
+		t.Errorf("%v is not descending", key[1])
+	}
+
+	if _, err := NewIndexKeyWith(idx2, 1, "x", 2); err == nil {
+		t.Errorf("expected error for too many key elements")
+	}
+}
+
+func TestIndexElementNullOrder(t *testing.T) {
+	s1 := NewSchema()
+	e1 := NewElement().SetName("a").SetType(Int64Type)
+	e2 := NewElement().SetName("b").SetType(StringType)
+	s1.AddElement(e1)
+	s1.AddElement(e2)
+
+	idx := NewIndex().SetName("idx").SetType(SecondaryIndex)
+	idx.AddElement(e1)
+	idx.AddElement(e2)
+	idx.SetElementNullOrder("a", NullsLast)
+	idx.SetElementOrder("b", Descending)
+	idx.SetElementNullOrder("b", NullsFirst)
+	s1.AddIndex(idx)
+
+	s2, err := NewSchemaWith(s1.Data())
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx2, err := s2.FindIndex("idx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nulls := idx2.ElementNullOrder("a"); nulls != NullsLast {
+		t.Errorf("%v != %v", nulls, NullsLast)
+	}
+	if nulls := idx2.ElementNullOrder("b"); nulls != NullsFirst {
+		t.Errorf("%v != %v", nulls, NullsFirst)
+	}
+
+	key, err := NewIndexKeyWith(idx2, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for n, elem := range key {
+		if ov, ok := elem.(OrderedValue); !ok || (n == 0) != ov.IsNullsLast() {
+			t.Errorf("%v has an unexpected null order", elem)
+		}
+	}
+
+	if _, err := NewIndexKeyFrom(idx2, key); err != nil {
+		t.Error(err)
+	}
+	if _, err := NewIndexKeyFrom(idx2, NewKeyWith(nil)); !errors.Is(err, ErrInvalid) {
+		t.Errorf("null order of %v should be invalid: %v", NewKeyWith(nil), err)
+	}
+}
+
+func TestIndexElementCollation(t *testing.T) {
+	s1 := NewSchema()
+	e1 := NewElement().SetName("a").SetType(StringType)
+	e2 := NewElement().SetName("b").SetType(StringType)
+	s1.AddElement(e1)
+	s1.AddElement(e2)
+
+	idx := NewIndex().SetName("idx").SetType(SecondaryIndex)
+	idx.AddElement(e1)
+	idx.AddElement(e2)
+	idx.SetElementCollation("a", CaseFoldedCollation)
+	idx.SetElementOrder("a", Descending)
+	s1.AddIndex(idx)
+
+	s2, err := NewSchemaWith(s1.Data())
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx2, err := s2.FindIndex("idx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := idx2.ElementCollation("a"); c != CaseFoldedCollation {
+		t.Errorf("%v != %v", c, CaseFoldedCollation)
+	}
+	if c := idx2.ElementCollation("b"); c != BinaryCollation {
+		t.Errorf("%v != %v", c, BinaryCollation)
+	}
+
+	key, err := NewIndexKeyWith(idx2, "Apple", "Apple")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !key.Equal(NewKeyWith(Desc("apple"), "Apple")) {
+		t.Errorf("%v != %v", key, NewKeyWith(Desc("apple"), "Apple"))
+	}
+
+	if _, err := NewIndexKeyFrom(idx2, key); err != nil {
+		t.Error(err)
+	}
+	if _, err := NewIndexKeyFrom(idx2, NewKeyWith(Desc("Apple"))); !errors.Is(err, ErrInvalid) {
+		t.Errorf("collation of %v should be invalid: %v", NewKeyWith(Desc("Apple")), err)
+	}
+}
+
+func TestNewIndexKeyFrom(t *testing.T) {
+	idx := NewIndex().SetName("idx").SetType(SecondaryIndex)
+	idx.AddElement(NewElement().SetName("a").SetType(Int8Type))
+	idx.AddElement(NewElement().SetName("b").SetType(Float32Type))
+	idx.AddElement(NewElement().SetName("c").SetType(BinaryType))
+	idx.SetElementOrder("b", Descending)
+
+	key, err := NewIndexKeyFrom(idx, NewKeyWith(int64(-1), Desc(int64(2)), [2]byte{0x01, 0x02}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := key[0].(int8); !ok || v != -1 {
+		t.Errorf("%v (%T) != %v", key[0], key[0], int8(-1))
+	}
+	if ov, ok := key[1].(OrderedValue); !ok || !ov.IsDescending() || ov.Value != float32(2) {
+		t.Errorf("%v (%T) != %v", key[1], key[1], Desc(float32(2)))
+	}
+	if v, ok := key[2].([]byte); !ok || string(v) != "\x01\x02" {
+		t.Errorf("%v (%T) != % x", key[2], key[2], []byte{0x01, 0x02})
+	}
+
+	invalidKeys := []Key{
+		NewKeyWith(int64(128)),
+		NewKeyWith("a"),
+		NewKeyWith(int64(1), float32(2)),
+		NewKeyWith(int64(1), Desc(float32(2)), []byte{}, nil),
+	}
+	for _, key := range invalidKeys {
+		if _, err := NewIndexKeyFrom(idx, key); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%v should be invalid: %v", key, err)
+		}
+	}
+}
+
+func TestIndexInvalidElementOrders(t *testing.T) {
+	slots := []struct {
+		name string
+		idx  uint8
+	}{
+		{"orders", indexOrdersIdx},
+		{"nulls", indexNullsIdx},
+		{"collations", indexCollationsIdx},
+	}
+	values := []any{
+		[]uint8{0xFF},
+		[]any{"desc"},
+		"\x7F",
+		int64(1),
+	}
+
+	for _, slot := range slots {
+		for _, v := range values {
+			s1 := NewSchema()
+			e1 := NewElement().SetName("a").SetType(Int64Type)
+			s1.AddElement(e1)
+			idx := NewIndex().SetName("idx").SetType(SecondaryIndex)
+			idx.AddElement(e1)
+			s1.AddIndex(idx)
+			idx.Data().(indexMap)[slot.idx] = v
+
+			if _, err := NewSchemaWith(s1.Data()); !errors.Is(err, ErrInvalid) {
+				t.Errorf("%s (%v): %v", slot.name, v, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package document
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIntegerFrom(t *testing.T) {
+	t.Run("int64", func(t *testing.T) {
+		cases := []struct {
+			from     any
+			expected int64
+			ok       bool
+		}{
+			{int(-1), -1, true},
+			{int8(math.MinInt8), math.MinInt8, true},
+			{int16(math.MaxInt16), math.MaxInt16, true},
+			{int32(math.MinInt32), math.MinInt32, true},
+			{int64(math.MaxInt64), math.MaxInt64, true},
+			{uint8(1), 0, false},
+			{1.0, 0, false},
+			{"1", 0, false},
+		}
+		for _, c := range cases {
+			v, ok := Int64From(c.from)
+			if v != c.expected || ok != c.ok {
+				t.Errorf("%T(%v): (%d, %t) != (%d, %t)", c.from, c.from, v, ok, c.expected, c.ok)
+			}
+		}
+	})
+
+	t.Run("uint64", func(t *testing.T) {
+		cases := []struct {
+			from     any
+			expected uint64
+			ok       bool
+		}{
+			{uint(1), 1, true},
+			{uint8(math.MaxUint8), math.MaxUint8, true},
+			{uint16(math.MaxUint16), math.MaxUint16, true},
+			{uint32(math.MaxUint32), math.MaxUint32, true},
+			{uint64(math.MaxUint64), math.MaxUint64, true},
+			{int(1), 0, false},
+			{1.0, 0, false},
+			{"1", 0, false},
+		}
+		for _, c := range cases {
+			v, ok := Uint64From(c.from)
+			if v != c.expected || ok != c.ok {
+				t.Errorf("%T(%v): (%d, %t) != (%d, %t)", c.from, c.from, v, ok, c.expected, c.ok)
+			}
+		}
+	})
+
+	t.Run("alloc", func(t *testing.T) {
+		var v any = int64(42)
+		if n := testing.AllocsPerRun(100, func() { _, _ = Int64From(v) }); n != 0 {
+			t.Errorf("Int64From allocates %.1f times", n)
+		}
+		v = uint64(42)
+		if n := testing.AllocsPerRun(100, func() { _, _ = Uint64From(v) }); n != 0 {
+			t.Errorf("Uint64From allocates %.1f times", n)
+		}
+	})
+}
@@ -15,8 +15,12 @@
 package document
 
 import (
+	"bytes"
 	"fmt"
-	"strings"
+	"math"
+	"math/big"
+	"reflect"
+	"time"
 
 	"github.com/cybergarage/go-safecast/safecast"
 )
@@ -51,7 +55,7 @@ func (key Key) Compare(other Key) (int, error) {
 	minLen := min(other.Len(), key.Len())
 
 	for n := range minLen {
-		cmp, err := safecast.Compare(key[n], other[n])
+		cmp, err := compareKeyElements(key[n], other[n])
 		if err != nil {
 			return 0, fmt.Errorf("failed to compare key element %d: %w", n, err)
 		}
@@ -71,8 +75,11 @@ func (key Key) Compare(other Key) (int, error) {
 
 // Equal reports whether the two keys are equal.
 func (key Key) Equal(other Key) bool {
+	if key.Len() != other.Len() {
+		return false
+	}
 	for n, elem := range key {
-		if !safecast.Equal(elem, other[n]) {
+		if !equalKeyElements(elem, other[n]) {
 			return false
 		}
 	}
@@ -81,9 +88,245 @@ func (key Key) Equal(other Key) bool {
 
 // String returns a string representation of the key.
 func (key Key) String() string {
-	var s strings.Builder
-	for _, elem := range key {
-		s.WriteString(fmt.Sprintf("%v", elem))
+	return key.Format()
+}
+
+// compareKeyElements compares the two key elements, reversing the result for descending elements.
+func compareKeyElements(v1 any, v2 any) (int, error) {
+	ov1, ok1 := v1.(OrderedValue)
+	ov2, ok2 := v2.(OrderedValue)
+	if ok1 || ok2 {
+		ov := ov1
+		if !ok1 {
+			ov = ov2
+		}
+		if ok1 {
+			v1 = ov1.Value
+		}
+		if ok2 {
+			v2 = ov2.Value
+		}
+		// Null elements are placed by their null order regardless of the sort order
+		if (v1 == nil) != (v2 == nil) {
+			nulls := ov
+			switch {
+			case v1 == nil && ok1:
+				nulls = ov1
+			case v2 == nil && ok2:
+				nulls = ov2
+			}
+			if (v1 == nil) == nulls.IsNullsLast() {
+				return 1, nil
+			}
+			return -1, nil
+		}
+		cmp, err := compareKeyElements(v1, v2)
+		if err != nil {
+			return 0, err
+		}
+		if ov.IsDescending() {
+			return -cmp, nil
+		}
+		return cmp, nil
+	}
+	// Null elements sort before the other values
+	switch {
+	case v1 == nil && v2 == nil:
+		return 0, nil
+	case v1 == nil:
+		return -1, nil
+	case v2 == nil:
+		return 1, nil
+	}
+	if k1, ok := nestedKeyFrom(v1); ok {
+		if k2, ok := nestedKeyFrom(v2); ok {
+			return k1.Compare(k2)
+		}
+	}
+	if cmp, ok := compareNaNs(v1, v2); ok {
+		return cmp, nil
+	}
+	if b1, ok := keyElementBytes(v1); ok {
+		if b2, ok := keyElementBytes(v2); ok {
+			return bytes.Compare(b1, b2), nil
+		}
+	}
+	if cmp, ok := compareNumbers(v1, v2); ok {
+		return cmp, nil
+	}
+	return safecast.Compare(keyElementValue(v1), keyElementValue(v2))
+}
+
+// equalKeyElements reports whether the two key elements have the same value.
+func equalKeyElements(v1 any, v2 any) bool {
+	if ov, ok := v1.(OrderedValue); ok {
+		v1 = ov.Value
+	}
+	if ov, ok := v2.(OrderedValue); ok {
+		v2 = ov.Value
+	}
+	if k1, ok := nestedKeyFrom(v1); ok {
+		if k2, ok := nestedKeyFrom(v2); ok {
+			return k1.Equal(k2)
+		}
+		return false
+	}
+	if b1, ok := keyElementBytes(v1); ok {
+		if b2, ok := keyElementBytes(v2); ok {
+			return bytes.Equal(b1, b2)
+		}
+	}
+	if cmp, ok := compareNaNs(v1, v2); ok {
+		return cmp == 0
+	}
+	if cmp, ok := compareNumbers(v1, v2); ok {
+		return cmp == 0
+	}
+	return safecast.Equal(keyElementValue(v1), keyElementValue(v2))
+}
+
+// keyElementBytes returns the bytes if the specified key element is a byte slice or a fixed-length byte array.
+func keyElementBytes(v any) ([]byte, bool) {
+	if b, ok := v.([]byte); ok {
+		return b, true
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Array || rv.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, false
+	}
+	b := make([]byte, rv.Len())
+	reflect.Copy(reflect.ValueOf(b), rv)
+	return b, true
+}
+
+// keyNumber represents an exact numeric value of a key element, or an infinity when inf is not zero.
+type keyNumber struct {
+	rat *big.Rat
+	inf int
+}
+
+// keyNumberFrom returns the exact value if the specified key element is a number.
+func keyNumberFrom(v any) (keyNumber, bool) {
+	switch v := v.(type) {
+	case int, int8, int16, int32, int64:
+		var iv int64
+		if err := safecast.ToInt64(v, &iv); err != nil {
+			return keyNumber{}, false
+		}
+		return keyNumber{rat: new(big.Rat).SetInt64(iv)}, true
+	case uint, uint8, uint16, uint32, uint64:
+		var uv uint64
+		if err := safecast.ToUint64(v, &uv); err != nil {
+			return keyNumber{}, false
+		}
+		return keyNumber{rat: new(big.Rat).SetUint64(uv)}, true
+	case float32, float64:
+		var fv float64
+		if err := safecast.ToFloat64(v, &fv); err != nil {
+			return keyNumber{}, false
+		}
+		if math.IsInf(fv, 0) {
+			return keyNumber{inf: int(math.Copysign(1, fv))}, true
+		}
+		rat := new(big.Rat).SetFloat64(fv)
+		if rat == nil {
+			return keyNumber{}, false
+		}
+		return keyNumber{rat: rat}, true
+	case *big.Int:
+		if v == nil {
+			return keyNumber{}, false
+		}
+		return keyNumber{rat: new(big.Rat).SetInt(v)}, true
+	case *big.Float:
+		if v == nil {
+			return keyNumber{}, false
+		}
+		if v.IsInf() {
+			return keyNumber{inf: v.Sign()}, true
+		}
+		rat, _ := v.Rat(nil)
+		return keyNumber{rat: rat}, true
+	case Decimal:
+		return keyNumber{rat: v.Rat()}, true
+	}
+	return keyNumber{}, false
+}
+
+// compareNaNs compares the two key elements if either is a NaN float, which equals NaN and sorts after all other numbers.
+func compareNaNs(v1 any, v2 any) (int, bool) {
+	nan1 := isNaNKeyElement(v1)
+	nan2 := isNaNKeyElement(v2)
+	switch {
+	case nan1 && nan2:
+		return 0, true
+	case nan1:
+		_, ok := keyNumberFrom(v2)
+		return 1, ok
+	case nan2:
+		_, ok := keyNumberFrom(v1)
+		return -1, ok
+	}
+	return 0, false
+}
+
+func isNaNKeyElement(v any) bool {
+	switch v := v.(type) {
+	case float32:
+		return math.IsNaN(float64(v))
+	case float64:
+		return math.IsNaN(v)
+	}
+	return false
+}
+
+// compareNumbers compares the two key elements by their exact values if both are numbers of different types.
+func compareNumbers(v1 any, v2 any) (int, bool) {
+	if reflect.TypeOf(v1) == reflect.TypeOf(v2) {
+		switch v1.(type) {
+		case *big.Int, *big.Float, Decimal:
+		default:
+			// Numbers of the same built-in type are compared by safecast
+			return 0, false
+		}
+	}
+	n1, ok := keyNumberFrom(v1)
+	if !ok {
+		return 0, false
+	}
+	n2, ok := keyNumberFrom(v2)
+	if !ok {
+		return 0, false
+	}
+	if n1.inf != 0 || n2.inf != 0 {
+		switch {
+		case n1.inf == n2.inf:
+			return 0, true
+		case n1.inf < n2.inf:
+			return -1, true
+		default:
+			return 1, true
+		}
+	}
+	return n1.rat.Cmp(n2.rat), true
+}
+
+// keyElementValue returns the comparable value of the specified key element.
+func keyElementValue(v any) any {
+	switch v := v.(type) {
+	case time.Duration:
+		return int64(v)
+	}
+	return v
+}
+
+// nestedKeyFrom returns the nested key if the specified key element is a tuple.
+func nestedKeyFrom(v any) (Key, bool) {
+	switch v := v.(type) {
+	case Key:
+		return v, true
+	case []any:
+		return v, true
 	}
-	return s.String()
+	return nil, false
 }
@@ -35,3 +35,16 @@ type KeyCoder interface {
 	// KeyEncoder encodes the specified key into bytes.
 	KeyEncoder
 }
+
+// A KeyAppender appends encoded keys to byte slices, so that the callers can reuse their buffers.
+type KeyAppender interface {
+	// AppendKey appends the encoded bytes of the specified key to the specified byte slice and returns the extended byte slice.
+	AppendKey(dst []byte, key Key) ([]byte, error)
+}
+
+// A KeyRanger computes the ranges of the encoded keys.
+type KeyRanger interface {
+	// PrefixRange returns the range [begin, end) of the encoded keys beginning with all elements of the specified key prefix.
+	// The end is nil if the range has no upper bound.
+	PrefixRange(Key) ([]byte, []byte, error)
+}
@@ -0,0 +1,547 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package document
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The textual key syntax is a parenthesized list of comma-separated elements such as
+// ("users", 42, b"\x00\x01", 1.5), where each element keeps its Go type:
+//
+//	nil                          - null
+//	bool                         - true, false
+//	int64, float64               - 42, -1.5, 1e+21, inf, -inf, nan
+//	string                       - Go quoted string, "users"
+//	[]byte                       - b"\x00\x01", printable ASCII bytes are written as they are
+//	int, int8 ... uint64         - int8(-1), uint64(18446744073709551615)
+//	float32                      - float32(1.5)
+//	*big.Int                     - bigint(18446744073709551616)
+//	*big.Float                   - bigfloat(1.5), the exact decimal value of the binary float
+//	Decimal                      - decimal(1.50)
+//	time.Time                    - time("2025-01-02T03:04:05.000000006Z")
+//	time.Duration                - duration("1h0m0s")
+//	[16]byte                     - uuid("01234567-89ab-cdef-0123-456789abcdef")
+//	other byte arrays            - fixed(b"\x01\x02")
+//	Key, []any                   - nested keys, ("a", (1, 2))
+//	OrderedValue                 - asc(...), desc(...), desc(null nulls first)
+//
+// Elements of the other types are written as strings. Big floats are parsed only from decimal values
+// which are exactly representable as binary floats, so bigfloat(0.1) is rejected instead of rounded.
+// Ordered elements can not be nested, so asc(desc(1)) is rejected.
+
+const (
+	keyFormatNull  = "null"
+	keyFormatTrue  = "true"
+	keyFormatFalse = "false"
+	keyFormatInf   = "inf"
+	keyFormatNaN   = "nan"
+)
+
+// Format returns the textual representation of the key which ParseKey parses back to an equal key.
+func (key Key) Format() string {
+	var s strings.Builder
+	formatKeyElements(&s, key)
+	return s.String()
+}
+
+func formatKeyElements(s *strings.Builder, elems []any) {
+	s.WriteByte('(')
+	for n, elem := range elems {
+		if 0 < n {
+			s.WriteString(", ")
+		}
+		formatKeyElement(s, elem)
+	}
+	s.WriteByte(')')
+}
+
+// nolint: gocyclo
+func formatKeyElement(s *strings.Builder, elem any) {
+	switch v := elem.(type) {
+	case nil:
+		s.WriteString(keyFormatNull)
+	case bool:
+		s.WriteString(strconv.FormatBool(v))
+	case int64:
+		s.WriteString(strconv.FormatInt(v, 10))
+	case float64:
+		s.WriteString(formatKeyFloat(v, 64))
+	case string:
+		s.WriteString(strconv.Quote(v))
+	case []byte:
+		formatKeyBytes(s, v)
+	case int, int8, int16, int32, uint, uint8, uint16, uint32, uint64:
+		fmt.Fprintf(s, "%T(%d)", v, v)
+	case float32:
+		fmt.Fprintf(s, "float32(%s)", formatKeyFloat(float64(v), 32))
+	case *big.Int:
+		if v == nil {
+			s.WriteString(keyFormatNull)
+			return
+		}
+		fmt.Fprintf(s, "bigint(%s)", v.String())
+	case *big.Float:
+		if v == nil {
+			s.WriteString(keyFormatNull)
+			return
+		}
+		fmt.Fprintf(s, "bigfloat(%s)", formatKeyBigFloat(v))
+	case Decimal:
+		fmt.Fprintf(s, "decimal(%s)", v.String())
+	case time.Time:
+		fmt.Fprintf(s, "time(%s)", strconv.Quote(v.Format(time.RFC3339Nano)))
+	case time.Duration:
+		fmt.Fprintf(s, "duration(%s)", strconv.Quote(v.String()))
+	case [16]byte:
+		fmt.Fprintf(s, "uuid(\"%s-%s-%s-%s-%s\")",
+			hex.EncodeToString(v[0:4]), hex.EncodeToString(v[4:6]), hex.EncodeToString(v[6:8]),
+			hex.EncodeToString(v[8:10]), hex.EncodeToString(v[10:16]))
+	case Key:
+		formatKeyElements(s, v)
+	case []any:
+		formatKeyElements(s, v)
+	case OrderedValue:
+		s.WriteString(v.Order.String())
+		s.WriteByte('(')
+		formatKeyElement(s, v.Value)
+		if v.Nulls != NullsDefault {
+			s.WriteByte(' ')
+			s.WriteString(v.Nulls.String())
+		}
+		s.WriteByte(')')
+	default:
+		if b, ok := keyElementBytes(v); ok {
+			s.WriteString("fixed(")
+			formatKeyBytes(s, b)
+			s.WriteByte(')')
+			return
+		}
+		s.WriteString(strconv.Quote(fmt.Sprintf("%v", v)))
+	}
+}
+
+// formatKeyFloat returns the shortest representation of the float which always has a decimal point or an exponent.
+func formatKeyFloat(v float64, bitSize int) string {
+	switch {
+	case math.IsNaN(v):
+		return keyFormatNaN
+	case math.IsInf(v, 1):
+		return keyFormatInf
+	case math.IsInf(v, -1):
+		return "-" + keyFormatInf
+	}
+	str := strconv.FormatFloat(v, 'g', -1, bitSize)
+	if !strings.ContainsAny(str, ".e") {
+		str += ".0"
+	}
+	return str
+}
+
+// formatKeyBigFloat returns the exact decimal representation of the big float.
+func formatKeyBigFloat(v *big.Float) string {
+	if v.IsInf() {
+		if v.Sign() < 0 {
+			return "-" + keyFormatInf
+		}
+		return keyFormatInf
+	}
+	dec, err := NewDecimalFromBigFloat(v)
+	if err != nil {
+		return v.String()
+	}
+	return dec.String()
+}
+
+func formatKeyBytes(s *strings.Builder, b []byte) {
+	const hexDigits = "0123456789abcdef"
+	s.WriteString("b\"")
+	for _, c := range b {
+		if c < 0x20 || 0x7E < c || c == '"' || c == '\\' {
+			s.WriteString("\\x")
+			s.WriteByte(hexDigits[c>>4])
+			s.WriteByte(hexDigits[c&0x0F])
+			continue
+		}
+		s.WriteByte(c)
+	}
+	s.WriteByte('"')
+}
+
+// ParseKey parses the textual representation of a key returned by Key.Format.
+func ParseKey(str string) (Key, error) {
+	p := &keyParser{str: str}
+	p.skipSpaces()
+	key, err := p.parseKey()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.str) {
+		return nil, p.errorf("unexpected trailing characters")
+	}
+	return key, nil
+}
+
+type keyParser struct {
+	str string
+	pos int
+}
+
+func (p *keyParser) errorf(format string, args ...any) error {
+	return newErrKeyFormatInvalid(p.str, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *keyParser) skipSpaces() {
+	for p.pos < len(p.str) && strings.IndexByte(" \t\r\n", p.str[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *keyParser) peek() byte {
+	if len(p.str) <= p.pos {
+		return 0
+	}
+	return p.str[p.pos]
+}
+
+func (p *keyParser) expect(c byte) error {
+	p.skipSpaces()
+	if p.peek() != c {
+		return p.errorf("'%c' is expected", c)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *keyParser) parseKey() (Key, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	key := NewKey()
+	for {
+		p.skipSpaces()
+		if p.peek() == ')' {
+			p.pos++
+			return key, nil
+		}
+		if 0 < len(key) {
+			if err := p.expect(','); err != nil {
+				return nil, err
+			}
+		}
+		elem, err := p.parseElement()
+		if err != nil {
+			return nil, err
+		}
+		key = append(key, elem)
+	}
+}
+
+// nolint: gocyclo
+func (p *keyParser) parseElement() (any, error) {
+	p.skipSpaces()
+	c := p.peek()
+	switch {
+	case c == '(':
+		return p.parseKey()
+	case c == '"':
+		return p.parseQuoted()
+	case c == 'b' && p.pos+1 < len(p.str) && p.str[p.pos+1] == '"':
+		p.pos++
+		return p.parseBytes()
+	case c == '-' || c == '+' || c == '.' || ('0' <= c && c <= '9'):
+		return p.parseNumber()
+	}
+
+	ident := p.parseIdent()
+	switch ident {
+	case "":
+		return nil, p.errorf("element is expected")
+	case keyFormatNull:
+		return nil, nil
+	case keyFormatTrue:
+		return true, nil
+	case keyFormatFalse:
+		return false, nil
+	case keyFormatInf:
+		return math.Inf(1), nil
+	case keyFormatNaN:
+		return math.NaN(), nil
+	}
+
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	v, err := p.parseTypedElement(ident)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// nolint: gocyclo
+func (p *keyParser) parseTypedElement(ident string) (any, error) {
+	switch ident {
+	case Ascending.String(), Descending.String():
+		v, err := p.parseElement()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := v.(OrderedValue); ok {
+			return nil, p.errorf("nested order: %s", ident)
+		}
+		ov := Asc(v)
+		if ident == Descending.String() {
+			ov = Desc(v)
+		}
+		nulls, err := p.parseNullOrder()
+		if err != nil {
+			return nil, err
+		}
+		return ov.WithNulls(nulls), nil
+	case "int", "int8", "int16", "int32":
+		bitSizes := map[string]int{"int": strconv.IntSize, "int8": 8, "int16": 16, "int32": 32}
+		v, err := strconv.ParseInt(p.parseToken(), 10, bitSizes[ident])
+		if err != nil {
+			return nil, p.errorf("%s", err)
+		}
+		return reflect.ValueOf(v).Convert(keyFormatTypes[ident]).Interface(), nil
+	case "uint", "uint8", "uint16", "uint32", "uint64":
+		bitSizes := map[string]int{"uint": strconv.IntSize, "uint8": 8, "uint16": 16, "uint32": 32, "uint64": 64}
+		v, err := strconv.ParseUint(p.parseToken(), 10, bitSizes[ident])
+		if err != nil {
+			return nil, p.errorf("%s", err)
+		}
+		return reflect.ValueOf(v).Convert(keyFormatTypes[ident]).Interface(), nil
+	case "float32":
+		v, err := parseKeyFloat(p.parseToken(), 32)
+		if err != nil {
+			return nil, p.errorf("%s", err)
+		}
+		return float32(v), nil
+	case "bigint":
+		token := p.parseToken()
+		v, ok := new(big.Int).SetString(token, 10)
+		if !ok {
+			return nil, p.errorf("invalid big integer: %s", token)
+		}
+		return v, nil
+	case "bigfloat":
+		return p.parseBigFloat(p.parseToken())
+	case "decimal":
+		v, err := NewDecimalFromString(p.parseToken())
+		if err != nil {
+			return nil, p.errorf("%s", err)
+		}
+		return v, nil
+	case "time":
+		str, err := p.parseQuoted()
+		if err != nil {
+			return nil, err
+		}
+		v, err := time.Parse(time.RFC3339Nano, str)
+		if err != nil {
+			return nil, p.errorf("%s", err)
+		}
+		return v, nil
+	case "duration":
+		str, err := p.parseQuoted()
+		if err != nil {
+			return nil, err
+		}
+		v, err := time.ParseDuration(str)
+		if err != nil {
+			return nil, p.errorf("%s", err)
+		}
+		return v, nil
+	case "uuid":
+		str, err := p.parseQuoted()
+		if err != nil {
+			return nil, err
+		}
+		b, err := hex.DecodeString(strings.ReplaceAll(str, "-", ""))
+		if err != nil || len(b) != 16 {
+			return nil, p.errorf("invalid uuid: %s", str)
+		}
+		return [16]byte(b), nil
+	case "fixed":
+		if p.peek() != 'b' {
+			return nil, p.errorf("bytes are expected")
+		}
+		p.pos++
+		b, err := p.parseBytes()
+		if err != nil {
+			return nil, err
+		}
+		if len(b) == 0 {
+			return nil, p.errorf("empty fixed bytes")
+		}
+		fixed := reflect.New(reflect.ArrayOf(len(b), reflect.TypeFor[byte]())).Elem()
+		reflect.Copy(fixed, reflect.ValueOf(b))
+		return fixed.Interface(), nil
+	}
+	return nil, p.errorf("unknown type: %s", ident)
+}
+
+// parseNullOrder parses the optional null order following an ordered element such as desc(null nulls first).
+func (p *keyParser) parseNullOrder() (NullOrder, error) {
+	p.skipSpaces()
+	if p.peek() == ')' {
+		return NullsDefault, nil
+	}
+	if ident := p.parseIdent(); ident != "nulls" {
+		return NullsDefault, p.errorf("null order is expected")
+	}
+	p.skipSpaces()
+	switch ident := p.parseIdent(); ident {
+	case "first":
+		return NullsFirst, nil
+	case "last":
+		return NullsLast, nil
+	default:
+		return NullsDefault, p.errorf("unknown null order: %s", ident)
+	}
+}
+
+var keyFormatTypes = map[string]reflect.Type{
+	"int":    reflect.TypeFor[int](),
+	"int8":   reflect.TypeFor[int8](),
+	"int16":  reflect.TypeFor[int16](),
+	"int32":  reflect.TypeFor[int32](),
+	"uint":   reflect.TypeFor[uint](),
+	"uint8":  reflect.TypeFor[uint8](),
+	"uint16": reflect.TypeFor[uint16](),
+	"uint32": reflect.TypeFor[uint32](),
+	"uint64": reflect.TypeFor[uint64](),
+}
+
+func (p *keyParser) parseIdent() string {
+	start := p.pos
+	for p.pos < len(p.str) {
+		c := p.str[p.pos]
+		if ('a' <= c && c <= 'z') || ('0' <= c && c <= '9' && start < p.pos) {
+			p.pos++
+			continue
+		}
+		break
+	}
+	return p.str[start:p.pos]
+}
+
+// parseToken returns the number token such as -1.5e+10 or inf.
+func (p *keyParser) parseToken() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.str) && strings.IndexByte(" \t\r\n,()", p.str[p.pos]) < 0 {
+		p.pos++
+	}
+	return p.str[start:p.pos]
+}
+
+func (p *keyParser) parseNumber() (any, error) {
+	token := p.parseToken()
+	if strings.ContainsAny(token, ".eEin") {
+		v, err := parseKeyFloat(token, 64)
+		if err != nil {
+			return nil, p.errorf("%s", err)
+		}
+		return v, nil
+	}
+	v, err := strconv.ParseInt(token, 10, 64)
+	if err != nil {
+		return nil, p.errorf("%s", err)
+	}
+	return v, nil
+}
+
+func parseKeyFloat(token string, bitSize int) (float64, error) {
+	switch token {
+	case keyFormatInf, "+" + keyFormatInf:
+		return math.Inf(1), nil
+	case "-" + keyFormatInf:
+		return math.Inf(-1), nil
+	case keyFormatNaN:
+		return math.NaN(), nil
+	}
+	return strconv.ParseFloat(token, bitSize)
+}
+
+func (p *keyParser) parseBigFloat(token string) (*big.Float, error) {
+	switch token {
+	case keyFormatInf, "+" + keyFormatInf:
+		return new(big.Float).SetInf(false), nil
+	case "-" + keyFormatInf:
+		return new(big.Float).SetInf(true), nil
+	}
+	dec, err := NewDecimalFromString(token)
+	if err != nil {
+		return nil, p.errorf("%s", err)
+	}
+	// Enough precision to hold the numerator exactly, so that only non-binary fractions are rounded
+	rat := dec.Rat()
+	prec := max(64, uint(rat.Num().BitLen()))
+	v := new(big.Float).SetPrec(prec).SetRat(rat)
+	if v.Acc() != big.Exact {
+		return nil, p.errorf("inexact big float: %s", token)
+	}
+	return v, nil
+}
+
+// parseQuoted parses a Go quoted string.
+func (p *keyParser) parseQuoted() (string, error) {
+	p.skipSpaces()
+	if p.peek() != '"' {
+		return "", p.errorf("string is expected")
+	}
+	start := p.pos
+	p.pos++
+	for p.pos < len(p.str) {
+		switch p.str[p.pos] {
+		case '\\':
+			p.pos += 2
+			continue
+		case '"':
+			p.pos++
+			str, err := strconv.Unquote(p.str[start:p.pos])
+			if err != nil {
+				return "", p.errorf("%s", err)
+			}
+			return str, nil
+		}
+		p.pos++
+	}
+	return "", p.errorf("unterminated string")
+}
+
+// parseBytes parses a bytes literal after the b prefix, whose escapes are the same as Go strings.
+func (p *keyParser) parseBytes() ([]byte, error) {
+	str, err := p.parseQuoted()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(str), nil
+}
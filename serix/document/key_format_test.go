@@ -0,0 +1,184 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package document
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestKeyFormat(t *testing.T) {
+	cases := []struct {
+		key Key
+		str string
+	}{
+		{NewKey(), `()`},
+		{NewKeyWith("users", int64(42), []byte{0x00, 0x01}, 1.5), `("users", 42, b"\x00\x01", 1.5)`},
+		{NewKeyWith(nil, true, false), `(null, true, false)`},
+		{NewKeyWith(float64(2), 1e21, math.Inf(1), math.Inf(-1), math.NaN()), `(2.0, 1e+21, inf, -inf, nan)`},
+		{NewKeyWith("a\"b\n", []byte(`a"\`)), `("a\"b\n", b"a\x22\x5c")`},
+		{NewKeyWith(1, int8(-1), uint64(math.MaxUint64), float32(1.5)), `(int(1), int8(-1), uint64(18446744073709551615), float32(1.5))`},
+		{NewKeyWith(time.Hour), `(duration("1h0m0s"))`},
+		{NewKeyWith([16]byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef, 0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef}), `(uuid("01234567-89ab-cdef-0123-456789abcdef"))`},
+		{NewKeyWith([2]byte{0x01, 0x02}), `(fixed(b"\x01\x02"))`},
+		{NewKeyWith("a", NewKeyWith(int64(1), []any{int64(2)})), `("a", (1, (2)))`},
+		{NewKeyWith(Desc("a"), Asc(int64(1))), `(desc("a"), asc(1))`},
+		{NewKeyWith(Asc(nil).WithNulls(NullsLast), Desc(nil).WithNulls(NullsFirst)), `(asc(null nulls last), desc(null nulls first))`},
+		{NewKeyWith(big.NewFloat(0.1)), `(bigfloat(0.1000000000000000055511151231257827021181583404541015625))`},
+	}
+	for _, c := range cases {
+		if str := c.key.Format(); str != c.str {
+			t.Errorf("%s != %s", str, c.str)
+		}
+	}
+
+	// Keys with the same concatenated elements are distinguishable
+	if NewKeyWith(1, 23).String() == NewKeyWith(12, 3).String() {
+		t.Errorf("%s == %s", NewKeyWith(1, 23), NewKeyWith(12, 3))
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	bigInt, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	keys := []Key{
+		NewKey(),
+		NewKeyWith("users", int64(42), []byte{0x00, 0x01}, 1.5),
+		NewKeyWith(nil, true, false, "", []byte{}),
+		NewKeyWith(int64(math.MinInt64), int64(math.MaxInt64), -0.0, 1e-300, math.MaxFloat64),
+		NewKeyWith(math.Inf(1), math.Inf(-1), math.NaN()),
+		NewKeyWith("日本語\x00\xff", []byte{0x00, 0xFF, '"', '\\', 'a'}),
+		NewKeyWith(int(-1), int8(math.MinInt8), int16(math.MaxInt16), int32(math.MinInt32)),
+		NewKeyWith(uint(1), uint8(math.MaxUint8), uint16(math.MaxUint16), uint32(math.MaxUint32), uint64(math.MaxUint64)),
+		NewKeyWith(float32(0.1), float32(math.Inf(-1)), float32(1e30)),
+		NewKeyWith(bigInt, big.NewFloat(0.1), big.NewFloat(-1e100), new(big.Float).SetInf(true)),
+		NewKeyWith(NewDecimal(big.NewInt(-150), 2), NewDecimal(big.NewInt(1), 30)),
+		NewKeyWith(time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC), time.Date(2025, 1, 2, 3, 4, 5, 0, time.FixedZone("", 9*3600))),
+		NewKeyWith(time.Duration(math.MinInt64), time.Duration(0), 1500*time.Millisecond),
+		NewKeyWith([16]byte{0xFF}, [4]byte{0x00, 'a', 0xFF, '"'}, [1]byte{0x00}),
+		NewKeyWith("a", NewKeyWith(int64(1), NewKeyWith(), NewKeyWith(nil, "b"))),
+		NewKeyWith(Desc("a"), Asc(int64(1)), Desc(NewKeyWith(int64(1), Desc([]byte{0x01})))),
+		NewKeyWith(Asc(nil).WithNulls(NullsLast), Desc("a").WithNulls(NullsFirst)),
+	}
+	for _, key := range keys {
+		str := key.Format()
+		parsed, err := ParseKey(str)
+		if err != nil {
+			t.Errorf("%s: %s", str, err)
+			continue
+		}
+		if parsed.Format() != str {
+			t.Errorf("%s != %s", parsed.Format(), str)
+		}
+		if err := equalKeyFormatTypes(key, parsed); err != nil {
+			t.Errorf("%s: %s", str, err)
+		}
+	}
+
+	valids := []struct {
+		str string
+		key Key
+	}{
+		{` ( "a" ,1  ) `, NewKeyWith("a", int64(1))},
+		{`(+1.5, -2, .5)`, NewKeyWith(1.5, int64(-2), 0.5)},
+		{`("é", b"é")`, NewKeyWith("é", []byte("é"))},
+	}
+	for _, v := range valids {
+		key, err := ParseKey(v.str)
+		if err != nil {
+			t.Errorf("%s: %s", v.str, err)
+			continue
+		}
+		if !key.Equal(v.key) {
+			t.Errorf("%s != %s", key, v.key)
+		}
+	}
+
+	invalids := []string{
+		``,
+		`"a"`,
+		`(`,
+		`("a"`,
+		`("a" 1)`,
+		`("a"))`,
+		`(1, 2) x`,
+		`(,)`,
+		`(1,)`,
+		`("a", 1, )`,
+		`(foo)`,
+		`(foo(1))`,
+		`(int8(128))`,
+		`(uint(-1))`,
+		`(99999999999999999999)`,
+		`(b"\xzz")`,
+		`("abc)`,
+		`(uuid("0123"))`,
+		`(fixed(b""))`,
+		`(time("yesterday"))`,
+		`(bigint(1.5))`,
+		`(decimal(1e-99999))`,
+		`(decimal(1e2000000000))`,
+		`(bigfloat(0.1))`,
+		`(bigfloat(1e99999))`,
+		`(asc(desc(1)))`,
+		`(desc(asc(null) nulls first))`,
+		`(asc(null nulls))`,
+		`(desc(null first))`,
+	}
+	for _, str := range invalids {
+		if key, err := ParseKey(str); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%s: %v (%v)", str, key, err)
+		}
+	}
+}
+
+func equalKeyFormatTypes(v1 any, v2 any) error {
+	switch v1 := v1.(type) {
+	case Key:
+		return equalKeyFormatTypes([]any(v1), v2)
+	case []any:
+		var elems []any
+		switch v2 := v2.(type) {
+		case Key:
+			elems = v2
+		case []any:
+			elems = v2
+		default:
+			return fmt.Errorf("%T != %T", v1, v2)
+		}
+		if len(v1) != len(elems) {
+			return fmt.Errorf("%d != %d", len(v1), len(elems))
+		}
+		for n := range v1 {
+			if err := equalKeyFormatTypes(v1[n], elems[n]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case OrderedValue:
+		ov, ok := v2.(OrderedValue)
+		if !ok || v1.Order != ov.Order || v1.Nulls != ov.Nulls {
+			return fmt.Errorf("%v != %v", v1, v2)
+		}
+		return equalKeyFormatTypes(v1.Value, ov.Value)
+	}
+	if fmt.Sprintf("%T", v1) != fmt.Sprintf("%T", v2) {
+		return fmt.Errorf("%T != %T", v1, v2)
+	}
+	return nil
+}
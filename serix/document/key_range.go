@@ -0,0 +1,53 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package document
+
+// KeyNext returns the strict successor of the specified encoded key, the smallest byte string
+// which sorts after the key, so that [key, KeyNext(key)) contains only the key itself.
+func KeyNext(encoded []byte) []byte {
+	next := make([]byte, len(encoded), len(encoded)+1)
+	copy(next, encoded)
+	return append(next, 0x00)
+}
+
+// KeyStrinc returns the smallest byte string which sorts after all byte strings beginning with
+// the specified prefix, like the strinc function of FoundationDB. The trailing 0xFF bytes are removed
+// and the last byte is incremented. KeyStrinc returns nil if the prefix is empty or has only 0xFF bytes,
+// because such a prefix has no upper bound.
+func KeyStrinc(prefix []byte) []byte {
+	n := len(prefix)
+	for 0 < n && prefix[n-1] == 0xFF {
+		n--
+	}
+	if n == 0 {
+		return nil
+	}
+	end := make([]byte, n)
+	copy(end, prefix[:n])
+	end[n-1]++
+	return end
+}
+
+// KeyPrefixRange returns the range [begin, end) of the encoded keys beginning with all elements of
+// the specified key prefix using the specified encoder. The encoder must encode each element to a
+// prefix-free byte string, as strings and bytes are encoded with terminators, so that a prefix on a string
+// element does not include longer strings. The end is nil if the range has no upper bound.
+func KeyPrefixRange(encoder KeyEncoder, prefix Key) ([]byte, []byte, error) {
+	begin, err := encoder.EncodeKey(prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	return begin, KeyStrinc(begin), nil
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package document
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestKeyStrinc(t *testing.T) {
+	cases := []struct {
+		prefix []byte
+		end    []byte
+	}{
+		{[]byte{}, nil},
+		{[]byte{0xFF, 0xFF}, nil},
+		{[]byte{0x00}, []byte{0x01}},
+		{[]byte{0x30, 0x61, 0x00, 0x00}, []byte{0x30, 0x61, 0x00, 0x01}},
+		{[]byte{0x01, 0xFE, 0xFF, 0xFF}, []byte{0x01, 0xFF}},
+	}
+	for _, c := range cases {
+		end := KeyStrinc(c.prefix)
+		if !bytes.Equal(end, c.end) || (end == nil) != (c.end == nil) {
+			t.Errorf("% x: % x != % x", c.prefix, end, c.end)
+		}
+	}
+
+	prefix := []byte{0x01, 0xFE}
+	KeyStrinc(prefix)
+	if !bytes.Equal(prefix, []byte{0x01, 0xFE}) {
+		t.Errorf("prefix is modified: % x", prefix)
+	}
+}
+
+func TestKeyNext(t *testing.T) {
+	key := []byte{0x30, 0x61, 0x00, 0x00}
+	next := KeyNext(key)
+	if !bytes.Equal(next, []byte{0x30, 0x61, 0x00, 0x00, 0x00}) {
+		t.Errorf("% x", next)
+	}
+	if bytes.Compare(key, next) >= 0 {
+		t.Errorf("% x should be < % x", key, next)
+	}
+}
@@ -1,6 +1,7 @@
 package document
 
 import (
+	"math"
 	"testing"
 )
 
@@ -75,6 +76,88 @@ func TestKeyCompare(t *testing.T) {
 		if cmp, err := c.Compare(d); err != nil || cmp != 0 {
 			t.Fatalf("expected 10.0==10, cmp=%d err=%v", cmp, err)
 		}
+
+		e := NewKeyWith(int8(5))
+		f := NewKeyWith(int64(1000))
+		if cmp, err := e.Compare(f); err != nil || cmp >= 0 {
+			t.Fatalf("expected 5<1000, cmp=%d err=%v", cmp, err)
+		}
+
+		g := NewKeyWith(int64(1))
+		h := NewKeyWith(float64(1.5))
+		if cmp, err := g.Compare(h); err != nil || cmp >= 0 {
+			t.Fatalf("expected 1<1.5, cmp=%d err=%v", cmp, err)
+		}
+	})
+
+	t.Run("nan", func(t *testing.T) {
+		nan := NewKeyWith(math.NaN())
+		if cmp, err := nan.Compare(NewKeyWith(float32(math.NaN()))); err != nil || cmp != 0 {
+			t.Fatalf("expected NaN==NaN, cmp=%d err=%v", cmp, err)
+		}
+		for _, v := range []any{math.Inf(1), float32(math.Inf(1)), int64(math.MaxInt64), uint64(math.MaxUint64)} {
+			if cmp, err := nan.Compare(NewKeyWith(v)); err != nil || cmp <= 0 {
+				t.Fatalf("expected NaN>%v, cmp=%d err=%v", v, cmp, err)
+			}
+			if cmp, err := NewKeyWith(v).Compare(nan); err != nil || cmp >= 0 {
+				t.Fatalf("expected %v<NaN, cmp=%d err=%v", v, cmp, err)
+			}
+		}
+		if !nan.Equal(NewKeyWith(math.Float64frombits(0xFFF8000000000000))) {
+			t.Fatalf("expected NaN==-NaN")
+		}
+	})
+
+	t.Run("descending", func(t *testing.T) {
+		a := NewKeyWith(1, Desc("b"))
+		b := NewKeyWith(1, Desc("a"))
+		if cmp, err := a.Compare(b); err != nil || cmp >= 0 {
+			t.Fatalf("expected a<b, cmp=%d err=%v", cmp, err)
+		}
+		if cmp, err := b.Compare(a); err != nil || cmp <= 0 {
+			t.Fatalf("expected b>a, cmp=%d err=%v", cmp, err)
+		}
+		if !NewKeyWith(Desc(1)).Equal(NewKeyWith(1)) {
+			t.Fatalf("expected desc(1)==1")
+		}
+	})
+
+	t.Run("nulls", func(t *testing.T) {
+		cases := []struct {
+			null  OrderedValue
+			value OrderedValue
+			cmp   int
+		}{
+			{Asc(nil), Asc(1), -1},
+			{Desc(nil), Desc(1), 1},
+			{Asc(nil).WithNulls(NullsLast), Asc(1).WithNulls(NullsLast), 1},
+			{Desc(nil).WithNulls(NullsFirst), Desc(1).WithNulls(NullsFirst), -1},
+			{Desc(nil).WithNulls(NullsFirst), Desc(1), -1},
+		}
+		for _, c := range cases {
+			a := NewKeyWith(c.null, "b")
+			b := NewKeyWith(c.value, "a")
+			if cmp, err := a.Compare(b); err != nil || cmp != c.cmp {
+				t.Fatalf("expected %d for %v vs %v, cmp=%d err=%v", c.cmp, a, b, cmp, err)
+			}
+			if cmp, err := b.Compare(a); err != nil || cmp != -c.cmp {
+				t.Fatalf("expected %d for %v vs %v, cmp=%d err=%v", -c.cmp, b, a, cmp, err)
+			}
+		}
+		if cmp, err := NewKeyWith(nil).Compare(NewKeyWith(Asc(nil).WithNulls(NullsLast))); err != nil || cmp != 0 {
+			t.Fatalf("expected null==null, cmp=%d err=%v", cmp, err)
+		}
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		a := NewKeyWith("t", NewKeyWith(2024, 1), 1)
+		b := NewKeyWith("t", []any{2024, 2}, 0)
+		if cmp, err := a.Compare(b); err != nil || cmp >= 0 {
+			t.Fatalf("expected a<b, cmp=%d err=%v", cmp, err)
+		}
+		if !NewKeyWith(NewKeyWith(1, "a")).Equal(NewKeyWith([]any{1, "a"})) {
+			t.Fatalf("expected nested keys to be equal")
+		}
 	})
 
 	t.Run("incomparable-types", func(t *testing.T) {
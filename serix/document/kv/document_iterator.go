@@ -0,0 +1,133 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"bytes"
+
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+// DocumentIterator represents an iterator over the documents matched by a query.
+type DocumentIterator struct {
+	store   *DocumentStore
+	db      string
+	col     document.Collection
+	query   *Query
+	it      Iterator
+	count   int
+	key     []byte
+	doc     document.MapObject
+	hasNext bool
+	err     error
+}
+
+// Query returns an iterator over the documents of the collection matched by the specified query.
+func (store *DocumentStore) Query(db string, col document.Collection, q *Query) (*DocumentIterator, error) {
+	docType, err := store.DocumentType()
+	if err != nil {
+		return nil, err
+	}
+	begin, end, err := q.KeyRange(store.keyCoder, docType, db, col.Name())
+	if err != nil {
+		return nil, err
+	}
+	it, err := store.rw.Range(begin, end, q.Order())
+	if err != nil {
+		return nil, err
+	}
+	return &DocumentIterator{
+		store:   store,
+		db:      db,
+		col:     col,
+		query:   q,
+		it:      it,
+		count:   0,
+		key:     nil,
+		doc:     nil,
+		hasNext: true,
+		err:     nil,
+	}, nil
+}
+
+// Next advances the iterator to the next document, and returns false if there are no more documents
+// or an error occurs.
+func (it *DocumentIterator) Next() bool {
+	it.doc = nil
+	if !it.hasNext {
+		return false
+	}
+	limit := it.query.Limit()
+	if (0 < limit && limit <= it.count) || !it.it.Next() {
+		it.hasNext = false
+		return false
+	}
+	doc, err := it.document(it.it.Key(), it.it.Value())
+	if err != nil {
+		it.hasNext = false
+		it.err = err
+		return false
+	}
+	it.key = bytes.Clone(it.it.Key())
+	it.doc = doc
+	it.count++
+	return true
+}
+
+// Document returns the current document.
+func (it *DocumentIterator) Document() document.MapObject {
+	return it.doc
+}
+
+// Err returns the error which stopped the iteration.
+func (it *DocumentIterator) Err() error {
+	return it.err
+}
+
+// Continuation returns the continuation token to resume the query after the last returned document,
+// which is the encoded index key of the document, or nil if no documents are returned.
+func (it *DocumentIterator) Continuation() []byte {
+	return it.key
+}
+
+// document returns the document of the specified index entry.
+func (it *DocumentIterator) document(key []byte, value []byte) (document.MapObject, error) {
+	store := it.store
+	if it.query.Index().Type() != document.PrimaryIndex {
+		docType, err := store.DocumentType()
+		if err != nil {
+			return nil, err
+		}
+		objKey, err := NewKeyCoder(store.keyCoder).ParseKey(key)
+		if err != nil {
+			return nil, err
+		}
+		_, pk, err := objKey.SplitElements(len(it.query.Index().Elements()))
+		if err != nil {
+			return nil, err
+		}
+		docKey := NewDocumentKey(docType, it.db, it.col.Name(), pk)
+		kb, err := store.keyCoder.EncodeKey(docKey)
+		if err != nil {
+			return nil, err
+		}
+		return store.getDocument(docKey, kb)
+	}
+	obj, err := store.objectCoder.DecodeObject(bytes.NewReader(value))
+	if err != nil {
+		return nil, err
+	}
+	return document.NewMapObjectFrom(obj)
+}
@@ -0,0 +1,266 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+// DocumentStore represents a document store which persists the documents of collections in a key-value store.
+// The documents are stored under the document keys of their primary keys, which are encoded by the key coder,
+// and their values are encoded by the object coder. The document keys have the document type of the object coder
+// in their headers, so that the object coder of a stored document is resolved from its key. The index entries of the secondary indexes are stored
+// with empty values, and they are maintained with the documents.
+type DocumentStore struct {
+	rw          ReadWriter
+	keyCoder    document.KeyCoder
+	objectCoder document.ObjectCoder
+	indexer     *Indexer
+}
+
+// NewDocumentStore returns a new document store on the specified key-value store with the specified key and object coders.
+// A transaction of kv.Store can be specified to write the documents and their index entries atomically.
+func NewDocumentStore(rw ReadWriter, keyCoder document.KeyCoder, objectCoder document.ObjectCoder) *DocumentStore {
+	return &DocumentStore{
+		rw:          rw,
+		keyCoder:    keyCoder,
+		objectCoder: objectCoder,
+		indexer:     NewIndexer(keyCoder),
+	}
+}
+
+// KeyCoder returns the key coder of the store.
+func (store *DocumentStore) KeyCoder() document.KeyCoder {
+	return store.keyCoder
+}
+
+// SetObjectCoder sets the specified object coder to encode and decode the documents. It returns an error wrapping
+// document.ErrNotSupported if the object coder has no document type, or an error wrapping ErrConflict if the store
+// already has documents of another document type, which the object coder could not decode.
+func (store *DocumentStore) SetObjectCoder(coder document.ObjectCoder) error {
+	docType, err := DocumentTypeForObjectCoder(coder)
+	if err != nil {
+		return err
+	}
+	for _, t := range DocumentTypes() {
+		if t == docType {
+			continue
+		}
+		begin, end, err := document.KeyPrefixRange(store.keyCoder, NewKeyWith(NewKeyHeaderWith(DocumentObject, t), document.NewKey()))
+		if err != nil {
+			return err
+		}
+		it, err := store.rw.Range(begin, end, document.Ascending)
+		if err != nil {
+			return err
+		}
+		if it.Next() {
+			return newErrDocumentTypeConflict(t)
+		}
+	}
+	store.objectCoder = coder
+	return nil
+}
+
+// ObjectCoder returns the object coder of the store.
+func (store *DocumentStore) ObjectCoder() document.ObjectCoder {
+	return store.objectCoder
+}
+
+// DocumentType returns the document type of the object coder of the store, or returns an error wrapping
+// document.ErrNotSupported if the object coder has no document type.
+func (store *DocumentStore) DocumentType() (DocumentType, error) {
+	return DocumentTypeForObjectCoder(store.objectCoder)
+}
+
+// Indexer returns the indexer of the store.
+func (store *DocumentStore) Indexer() *Indexer {
+	return store.indexer
+}
+
+// InsertDocument inserts the specified document into the collection, or returns an error wrapping
+// document.ErrExist if a document of the same primary key already exists.
+func (store *DocumentStore) InsertDocument(db string, col document.Collection, obj document.MapObject) error {
+	docType, err := store.DocumentType()
+	if err != nil {
+		return err
+	}
+	key, err := NewDocumentKeyFrom(docType, db, col, obj)
+	if err != nil {
+		return err
+	}
+	kb, err := store.keyCoder.EncodeKey(key)
+	if err != nil {
+		return err
+	}
+	_, err = store.getDocument(key, kb)
+	switch {
+	case err == nil:
+		return document.NewErrObjectExist(key)
+	case !errors.Is(err, document.ErrNotExist):
+		return err
+	}
+	diff, err := store.indexer.Diff(db, col, nil, obj)
+	if err != nil {
+		return err
+	}
+	if err := store.setDocument(kb, obj); err != nil {
+		return err
+	}
+	return store.updateIndexes(diff)
+}
+
+// GetDocument returns the document of the specified primary key values in the collection, or returns
+// an error wrapping document.ErrNotExist if the document does not exist.
+func (store *DocumentStore) GetDocument(db string, col document.Collection, pk ...any) (document.MapObject, error) {
+	docType, err := store.DocumentType()
+	if err != nil {
+		return nil, err
+	}
+	key, err := NewDocumentKeyWith(docType, db, col, pk...)
+	if err != nil {
+		return nil, err
+	}
+	kb, err := store.keyCoder.EncodeKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return store.getDocument(key, kb)
+}
+
+// UpdateDocument replaces the document of the same primary key in the collection with the specified document,
+// or returns an error wrapping document.ErrNotExist if the document does not exist.
+func (store *DocumentStore) UpdateDocument(db string, col document.Collection, obj document.MapObject) error {
+	docType, err := store.DocumentType()
+	if err != nil {
+		return err
+	}
+	key, err := NewDocumentKeyFrom(docType, db, col, obj)
+	if err != nil {
+		return err
+	}
+	kb, err := store.keyCoder.EncodeKey(key)
+	if err != nil {
+		return err
+	}
+	oldObj, err := store.getDocument(key, kb)
+	if err != nil {
+		return err
+	}
+	diff, err := store.indexer.Diff(db, col, oldObj, obj)
+	if err != nil {
+		return err
+	}
+	if err := store.setDocument(kb, obj); err != nil {
+		return err
+	}
+	return store.updateIndexes(diff)
+}
+
+// DeleteDocument deletes the document of the specified primary key values in the collection, or returns
+// an error wrapping document.ErrNotExist if the document does not exist.
+func (store *DocumentStore) DeleteDocument(db string, col document.Collection, pk ...any) error {
+	docType, err := store.DocumentType()
+	if err != nil {
+		return err
+	}
+	key, err := NewDocumentKeyWith(docType, db, col, pk...)
+	if err != nil {
+		return err
+	}
+	kb, err := store.keyCoder.EncodeKey(key)
+	if err != nil {
+		return err
+	}
+	oldObj, err := store.getDocument(key, kb)
+	if err != nil {
+		return err
+	}
+	diff, err := store.indexer.Diff(db, col, oldObj, nil)
+	if err != nil {
+		return err
+	}
+	if err := store.rw.Delete(kb); err != nil {
+		return err
+	}
+	return store.updateIndexes(diff)
+}
+
+func (store *DocumentStore) getDocument(key Key, kb []byte) (document.MapObject, error) {
+	b, err := store.rw.Get(kb)
+	if err != nil {
+		if errors.Is(err, document.ErrNotExist) {
+			return nil, document.NewErrObjectNotExist(key)
+		}
+		return nil, err
+	}
+	obj, err := store.objectCoder.DecodeObject(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	return document.NewMapObjectFrom(obj)
+}
+
+func (store *DocumentStore) setDocument(kb []byte, obj document.MapObject) error {
+	var w bytes.Buffer
+	if err := store.objectCoder.EncodeObject(&w, obj); err != nil {
+		return err
+	}
+	return store.rw.Set(kb, w.Bytes())
+}
+
+func (store *DocumentStore) updateIndexes(diff *IndexDiff) error {
+	for _, kb := range diff.Deletes {
+		if err := store.rw.Delete(kb); err != nil {
+			return err
+		}
+	}
+	for _, kb := range diff.Inserts {
+		if err := store.rw.Set(kb, []byte{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewDocumentKeyWith returns a new document key of the specified document type and primary key values in the collection.
+// The values are ordered and collated by the primary index of the collection, and must have all elements of the index.
+func NewDocumentKeyWith(docType DocumentType, db string, col document.Collection, pk ...any) (Key, error) {
+	idx, err := col.PrimaryIndex()
+	if err != nil {
+		return nil, err
+	}
+	if len(pk) != len(idx.Elements()) {
+		return nil, newErrPrimaryKeyInvalid(idx, document.NewKeyWith(pk...))
+	}
+	pkKey, err := newIndexKeyWith(idx, pk...)
+	if err != nil {
+		return nil, err
+	}
+	return NewDocumentKey(docType, db, col.Name(), pkKey), nil
+}
+
+// NewDocumentKeyFrom returns a new document key of the specified document type and document in the collection.
+// The primary key values are the document elements of the primary index elements of the collection.
+func NewDocumentKeyFrom(docType DocumentType, db string, col document.Collection, obj document.MapObject) (Key, error) {
+	pk, err := NewPrimaryKeyFrom(col, obj)
+	if err != nil {
+		return nil, err
+	}
+	return NewDocumentKey(docType, db, col.Name(), pk), nil
+}
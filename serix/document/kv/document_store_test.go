@@ -0,0 +1,260 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cybergarage/go-serix/serix/document"
+	"github.com/cybergarage/go-serix/serix/document/kv"
+	"github.com/cybergarage/go-serix/serix/plugins"
+	"github.com/cybergarage/go-serix/serix/plugins/document/key/composite"
+	"github.com/cybergarage/go-serix/serix/plugins/document/kv/memory"
+	"github.com/cybergarage/go-serix/serix/plugins/document/object/cbor"
+	"github.com/cybergarage/go-serix/serix/plugins/document/object/gzip"
+	"github.com/cybergarage/go-serix/serix/plugins/document/object/json"
+)
+
+func newTestCollection(t *testing.T) document.Collection {
+	t.Helper()
+	col := document.NewCollection()
+	col.SetName("users")
+	id := document.NewElement().SetName("id").SetType(document.Int64Type)
+	name := document.NewElement().SetName("name").SetType(document.StringType)
+	for _, elem := range []document.Element{id, name} {
+		if err := col.AddElement(elem); err != nil {
+			t.Fatal(err)
+		}
+	}
+	idx := document.NewIndex().SetName("pk").SetType(document.PrimaryIndex).AddElement(id)
+	if err := col.AddIndex(idx); err != nil {
+		t.Fatal(err)
+	}
+	nameIdx := document.NewIndex().SetName("name").SetType(document.SecondaryIndex).AddElement(name)
+	if err := col.AddIndex(nameIdx); err != nil {
+		t.Fatal(err)
+	}
+	return col
+}
+
+func TestDocumentStore(t *testing.T) {
+	coders := []document.ObjectCoder{
+		cbor.NewCoder(),
+		json.NewCoder(),
+	}
+
+	col := newTestCollection(t)
+
+	for _, coder := range coders {
+		t.Run(coder.Name(), func(t *testing.T) {
+			store := kv.NewDocumentStore(memory.NewStore(), composite.NewCoder(), cbor.NewCoder())
+			if err := store.SetObjectCoder(coder); err != nil {
+				t.Fatal(err)
+			}
+
+			docs := []document.MapObject{
+				{"id": int64(1), "name": "alice"},
+				{"id": int64(2), "name": "bob"},
+			}
+			for _, doc := range docs {
+				if err := store.InsertDocument("db", col, doc); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := store.InsertDocument("db", col, docs[0]); !errors.Is(err, document.ErrExist) {
+				t.Errorf("%v", err)
+			}
+
+			doc, err := store.GetDocument("db", col, int64(1))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(doc["name"]) != "alice" {
+				t.Errorf("%v != %v", doc, docs[0])
+			}
+
+			if err := store.UpdateDocument("db", col, document.MapObject{"id": int64(1), "name": "carol"}); err != nil {
+				t.Fatal(err)
+			}
+			doc, err = store.GetDocument("db", col, int64(1))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(doc["name"]) != "carol" {
+				t.Errorf("%v != %v", doc["name"], "carol")
+			}
+
+			if err := store.DeleteDocument("db", col, int64(2)); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := store.GetDocument("db", col, int64(2)); !errors.Is(err, document.ErrNotExist) {
+				t.Errorf("%v", err)
+			}
+			if err := store.DeleteDocument("db", col, int64(2)); !errors.Is(err, document.ErrNotExist) {
+				t.Errorf("%v", err)
+			}
+			if err := store.UpdateDocument("db", col, docs[1]); !errors.Is(err, document.ErrNotExist) {
+				t.Errorf("%v", err)
+			}
+
+			// Documents of other databases are not shared
+			if _, err := store.GetDocument("other", col, int64(1)); !errors.Is(err, document.ErrNotExist) {
+				t.Errorf("%v", err)
+			}
+		})
+	}
+}
+
+func TestInvalidDocumentKey(t *testing.T) {
+	col := newTestCollection(t)
+
+	if _, err := kv.NewDocumentKeyFrom(kv.CBOR, "db", col, document.MapObject{"name": "alice"}); !errors.Is(err, document.ErrNotExist) {
+		t.Errorf("%v", err)
+	}
+	if _, err := kv.NewDocumentKeyWith(kv.CBOR, "db", col); !errors.Is(err, document.ErrInvalid) {
+		t.Errorf("%v", err)
+	}
+	if _, err := kv.NewDocumentKeyWith(kv.CBOR, "db", document.NewCollection(), int64(1)); !errors.Is(err, document.ErrNotExist) {
+		t.Errorf("%v", err)
+	}
+
+	key, err := kv.NewDocumentKeyFrom(kv.CBOR, "db", col, document.MapObject{"id": int64(1), "name": "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objKey, err := kv.NewObjectKeyFrom(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objKey.Type() != kv.DocumentObject || objKey.Collection != "users" || objKey.PrimaryKey()[0] != int64(1) {
+		t.Errorf("%v", objKey)
+	}
+}
+
+func TestDocumentStoreObjectCoders(t *testing.T) {
+	col := newTestCollection(t)
+	mgr := plugins.NewManager()
+	keyCoder := kv.NewKeyCoder(composite.NewCoder())
+	doc := document.MapObject{"id": int64(1), "name": "alice"}
+
+	for _, docType := range []kv.DocumentType{kv.JSON, kv.JSONGzip, kv.CBORZlib} {
+		t.Run(docType.String(), func(t *testing.T) {
+			coder, err := mgr.ObjectCoderForDocumentType(docType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			kvStore := memory.NewStore()
+			store := kv.NewDocumentStore(kvStore, composite.NewCoder(), coder)
+			if err := store.InsertDocument("db", col, doc); err != nil {
+				t.Fatal(err)
+			}
+
+			// The document is decoded by the object coder resolved from its key header
+			it, err := kvStore.Range(nil, nil, document.Ascending)
+			if err != nil {
+				t.Fatal(err)
+			}
+			nDocs := 0
+			for it.Next() {
+				objKey, err := keyCoder.ParseKey(it.Key())
+				if err != nil {
+					t.Fatal(err)
+				}
+				if objKey.Type() != kv.DocumentObject {
+					continue
+				}
+				if objKey.DocumentType() != docType {
+					t.Errorf("%v != %v", objKey.DocumentType(), docType)
+				}
+				headerCoder, err := mgr.ObjectCoderForHeader(objKey.Header)
+				if err != nil {
+					t.Fatal(err)
+				}
+				obj, err := headerCoder.DecodeObject(bytes.NewReader(it.Value()))
+				if err != nil {
+					t.Fatal(err)
+				}
+				mobj, err := document.NewMapObjectFrom(obj)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if fmt.Sprint(mobj["name"]) != "alice" {
+					t.Errorf("%v != %v", mobj, doc)
+				}
+				nDocs++
+			}
+			if nDocs != 1 {
+				t.Errorf("%d != %d", nDocs, 1)
+			}
+
+			// The stored documents are not decodable by the object coders of other document types
+			if err := store.SetObjectCoder(cbor.NewCoder()); !errors.Is(err, kv.ErrConflict) {
+				t.Errorf("%v", err)
+			}
+			if err := store.SetObjectCoder(coder); err != nil {
+				t.Error(err)
+			}
+			if _, err := store.GetDocument("db", col, int64(1)); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	// Object coders without document types are not supported
+	store := kv.NewDocumentStore(memory.NewStore(), composite.NewCoder(), gzip.NewCoder())
+	if err := store.InsertDocument("db", col, doc); !errors.Is(err, document.ErrNotSupported) {
+		t.Errorf("%v", err)
+	}
+	if err := store.SetObjectCoder(gzip.NewCoder()); !errors.Is(err, document.ErrNotSupported) {
+		t.Errorf("%v", err)
+	}
+}
+
+func TestDocumentStoreTransaction(t *testing.T) {
+	col := newTestCollection(t)
+	kvStore := memory.NewStore()
+	doc := document.MapObject{"id": int64(1), "name": "alice"}
+
+	for _, committed := range []bool{false, true} {
+		tx, err := kvStore.Transact(true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		store := kv.NewDocumentStore(tx, composite.NewCoder(), cbor.NewCoder())
+		if err := store.InsertDocument("db", col, doc); err != nil {
+			t.Fatal(err)
+		}
+		if committed {
+			err = tx.Commit()
+		} else {
+			err = tx.Cancel()
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Only the document and the index entry of the committed transaction are stored
+	if n := kvStore.Len(); n != 2 {
+		t.Errorf("%d != %d", n, 2)
+	}
+	store := kv.NewDocumentStore(kvStore, composite.NewCoder(), cbor.NewCoder())
+	if _, err := store.GetDocument("db", col, int64(1)); err != nil {
+		t.Error(err)
+	}
+}
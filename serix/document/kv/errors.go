@@ -0,0 +1,77 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+var (
+	ErrConflict = errors.New("conflicted")
+)
+
+// NewErrTransactionReadOnly returns a new error that the read-only transaction is written.
+func NewErrTransactionReadOnly() error {
+	return fmt.Errorf("write in read-only transaction is %w", document.ErrNotSupported)
+}
+
+// NewErrTransactionClosed returns a new error that the closed transaction is used.
+func NewErrTransactionClosed() error {
+	return fmt.Errorf("closed transaction is %w", document.ErrInvalid)
+}
+
+// NewErrTransactionConflict returns a new error that the written key of the transaction is conflicted with another transaction.
+func NewErrTransactionConflict(key []byte) error {
+	return fmt.Errorf("key (% x) of transaction is %w", key, ErrConflict)
+}
+
+func newErrKeyInvalid(key Key) error {
+	return fmt.Errorf("key (%s) is %w", key.String(), document.ErrInvalid)
+}
+
+func newErrKeyHeaderInvalid(v any) error {
+	return fmt.Errorf("key header (%T:%v) is %w", v, v, document.ErrInvalid)
+}
+
+func newErrKeyElementsInvalid(key *ObjectKey, n int) error {
+	return fmt.Errorf("key (%s) has fewer elements than %d: %w", key.Key().String(), n, document.ErrInvalid)
+}
+
+func newErrPrimaryKeyInvalid(idx document.Index, pk Key) error {
+	return fmt.Errorf("primary key (%s) for index (%s) is %w", pk.String(), idx.Name(), document.ErrInvalid)
+}
+
+func newErrPrimaryKeyElementNotExist(idx document.Index, name string) error {
+	return fmt.Errorf("primary key element (%s) of index (%s) is %w", name, idx.Name(), document.ErrNotExist)
+}
+
+func newErrIndexElementValueInvalid(idx document.Index, elem document.Element, v any) error {
+	return fmt.Errorf("value (%T:%v) for element (%s) of index (%s) is %w", v, v, elem.Name(), idx.Name(), document.ErrInvalid)
+}
+
+func newErrObjectCoderNotSupported(coder document.ObjectCoder) error {
+	return fmt.Errorf("object coder (%s) is %w", coder.Name(), document.ErrNotSupported)
+}
+
+func newErrDocumentTypeConflict(t DocumentType) error {
+	return fmt.Errorf("stored documents of document type (%s) are %w", t.String(), ErrConflict)
+}
+
+func newErrQueryInvalid(q *Query) error {
+	return fmt.Errorf("query (%v) for index (%s) is %w", q.values, q.index.Name(), document.ErrInvalid)
+}
@@ -14,7 +14,12 @@
 
 package kv
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cybergarage/go-serix/serix/document"
+)
 
 type HeaderType byte
 
@@ -30,6 +35,47 @@ type Version byte
 // IndexType represents an index type.
 type IndexType byte
 
+// DocumentTypes returns all document types.
+func DocumentTypes() []DocumentType {
+	return []DocumentType{CBOR, JSON, Gob, CBORGzip, CBORZlib, JSONGzip, JSONZlib, GobGzip, GobZlib}
+}
+
+// CoderNames returns the names of the object coders in the encoding order, e.g. a serializer and a compressor.
+func (t DocumentType) CoderNames() []string {
+	return documentTypeCoderNames[t]
+}
+
+// String returns a string.
+func (t DocumentType) String() string {
+	return strings.Join(t.CoderNames(), "+")
+}
+
+// ObjectCoderName returns the name of the object coder of the document type, which is the name of the serializer
+// or the name of the chain coder created by document.NewChainCorder for the compressed document type.
+func (t DocumentType) ObjectCoderName() string {
+	names := t.CoderNames()
+	if len(names) == 1 {
+		return names[0]
+	}
+	return "multi(" + strings.Join(names, ",") + ")"
+}
+
+// DocumentTypeForObjectCoder returns the document type of the specified object coder, or returns an error
+// wrapping document.ErrNotSupported if the object coder has no document type.
+func DocumentTypeForObjectCoder(coder document.ObjectCoder) (DocumentType, error) {
+	for _, t := range DocumentTypes() {
+		if t.ObjectCoderName() == coder.Name() {
+			return t, nil
+		}
+	}
+	return 0, newErrObjectCoderNotSupported(coder)
+}
+
+// NewKeyHeaderWith creates a new key header of the specified header type and document type.
+func NewKeyHeaderWith(t HeaderType, docType DocumentType) KeyHeader {
+	return KeyHeader{byte(t), TypeFromHeaderByte(byte(docType)) | HeaderByteFromVersion(V1)}
+}
+
 // NewKeyHeaderFrom creates a new key header from the specified bytes.
 func NewKeyHeaderFrom(b []byte) KeyHeader {
 	var header KeyHeader
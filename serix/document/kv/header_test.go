@@ -56,6 +56,15 @@ func TestKeyHeader(t *testing.T) {
 				idx: IndexType(0),
 			},
 		},
+		{
+			header: NewKeyHeaderWith(DocumentObject, GobZlib),
+			expected: expected{
+				tp:  DocumentObject,
+				ver: V1,
+				doc: GobZlib,
+				idx: IndexType(0),
+			},
+		},
 		{
 			header: IndexKeyHeader,
 			expected: expected{
@@ -18,10 +18,41 @@ const (
 	V1 = Version(1)
 )
 
+// Document types represent the object coders of the stored documents, which are serializers
+// optionally followed by compressors.
 const (
-	CBOR = DocumentType(1)
+	CBOR     = DocumentType(1)
+	JSON     = DocumentType(2)
+	Gob      = DocumentType(3)
+	CBORGzip = DocumentType(4)
+	CBORZlib = DocumentType(5)
+	JSONGzip = DocumentType(6)
+	JSONZlib = DocumentType(7)
+	GobGzip  = DocumentType(8)
+	GobZlib  = DocumentType(9)
 )
 
+// Object coder names of the document types.
+const (
+	CBORCoderName = "cbor"
+	JSONCoderName = "json"
+	GobCoderName  = "gob"
+	GzipCoderName = "gzip"
+	ZlibCoderName = "zlib"
+)
+
+var documentTypeCoderNames = map[DocumentType][]string{
+	CBOR:     {CBORCoderName},
+	JSON:     {JSONCoderName},
+	Gob:      {GobCoderName},
+	CBORGzip: {CBORCoderName, GzipCoderName},
+	CBORZlib: {CBORCoderName, ZlibCoderName},
+	JSONGzip: {JSONCoderName, GzipCoderName},
+	JSONZlib: {JSONCoderName, ZlibCoderName},
+	GobGzip:  {GobCoderName, GzipCoderName},
+	GobZlib:  {GobCoderName, ZlibCoderName},
+}
+
 const (
 	DatabaseObject   = HeaderType('D')
 	CollectionObject = HeaderType('C')
@@ -48,5 +79,5 @@ func VertionFromHeaderByte(b byte) Version {
 }
 
 func TypeFromHeaderByte(b byte) byte {
-	return (b & 0x07)
+	return (b & 0x0F)
 }
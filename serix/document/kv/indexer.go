@@ -0,0 +1,204 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+// Indexer represents an indexer which generates the index entries of documents from the secondary indexes of their schemas.
+// An index entry is an index key which has the index element values and the primary key of the document,
+// so that the documents are found by scanning the index keys.
+type Indexer struct {
+	keyCoder document.KeyCoder
+}
+
+// IndexDiff represents the difference of the index entries between two versions of a document.
+type IndexDiff struct {
+	// Deletes are the encoded index keys which are deleted.
+	Deletes [][]byte
+	// Inserts are the encoded index keys which are inserted.
+	Inserts [][]byte
+}
+
+// NewIndexer returns a new indexer which encodes the index keys with the specified key coder.
+func NewIndexer(coder document.KeyCoder) *Indexer {
+	return &Indexer{
+		keyCoder: coder,
+	}
+}
+
+// KeyCoder returns the key coder of the indexer.
+func (indexer *Indexer) KeyCoder() document.KeyCoder {
+	return indexer.keyCoder
+}
+
+// IndexKeys returns the index keys of the specified document for all secondary indexes of the schema.
+// The index element values are ordered and collated by the index, and the missing elements are indexed as nulls.
+func (indexer *Indexer) IndexKeys(db string, schema document.Schema, obj document.MapObject) ([]Key, error) {
+	pk, err := NewPrimaryKeyFrom(schema, obj)
+	if err != nil {
+		return nil, err
+	}
+	idxes, err := schema.SecondaryIndexes()
+	if err != nil {
+		return nil, err
+	}
+	keys := []Key{}
+	for _, idx := range idxes {
+		values := []any{}
+		for _, elem := range idx.Elements() {
+			v, _ := documentElementFrom(obj, elem.Name())
+			values = append(values, v)
+		}
+		idxKey, err := newIndexKeyWith(idx, values...)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, NewIndexKey(db, schema.Name(), idx.Name(), idxKey, pk))
+	}
+	return keys, nil
+}
+
+// EncodeIndexKeys returns the encoded index keys of the specified document sorted in the byte order.
+func (indexer *Indexer) EncodeIndexKeys(db string, schema document.Schema, obj document.MapObject) ([][]byte, error) {
+	keys, err := indexer.IndexKeys(db, schema, obj)
+	if err != nil {
+		return nil, err
+	}
+	encKeys := [][]byte{}
+	for _, key := range keys {
+		b, err := indexer.keyCoder.EncodeKey(key)
+		if err != nil {
+			return nil, err
+		}
+		encKeys = append(encKeys, b)
+	}
+	slices.SortFunc(encKeys, bytes.Compare)
+	return slices.CompactFunc(encKeys, bytes.Equal), nil
+}
+
+// Diff returns the index entries to delete and insert when the specified old document is replaced with the new document.
+// A nil old document means an insertion, and a nil new document means a deletion. The index entries which are not
+// changed by the replacement are neither deleted nor inserted.
+func (indexer *Indexer) Diff(db string, schema document.Schema, oldObj document.MapObject, newObj document.MapObject) (*IndexDiff, error) {
+	oldKeys := [][]byte{}
+	if oldObj != nil {
+		keys, err := indexer.EncodeIndexKeys(db, schema, oldObj)
+		if err != nil {
+			return nil, err
+		}
+		oldKeys = keys
+	}
+	newKeys := [][]byte{}
+	if newObj != nil {
+		keys, err := indexer.EncodeIndexKeys(db, schema, newObj)
+		if err != nil {
+			return nil, err
+		}
+		newKeys = keys
+	}
+
+	// Both keys are sorted, so that the difference is computed by merging them
+	diff := &IndexDiff{
+		Deletes: [][]byte{},
+		Inserts: [][]byte{},
+	}
+	i, j := 0, 0
+	for i < len(oldKeys) || j < len(newKeys) {
+		switch {
+		case j == len(newKeys):
+			diff.Deletes = append(diff.Deletes, oldKeys[i])
+			i++
+		case i == len(oldKeys):
+			diff.Inserts = append(diff.Inserts, newKeys[j])
+			j++
+		default:
+			switch cmp := bytes.Compare(oldKeys[i], newKeys[j]); {
+			case cmp < 0:
+				diff.Deletes = append(diff.Deletes, oldKeys[i])
+				i++
+			case 0 < cmp:
+				diff.Inserts = append(diff.Inserts, newKeys[j])
+				j++
+			default:
+				i++
+				j++
+			}
+		}
+	}
+	return diff, nil
+}
+
+// NewPrimaryKeyFrom returns the primary key of the specified document, whose elements are the document elements
+// of the primary index elements ordered and collated by the primary index of the schema.
+func NewPrimaryKeyFrom(schema document.Schema, obj document.MapObject) (Key, error) {
+	idx, err := schema.PrimaryIndex()
+	if err != nil {
+		return nil, err
+	}
+	pk := []any{}
+	for _, elem := range idx.Elements() {
+		v, ok := documentElementFrom(obj, elem.Name())
+		if !ok {
+			return nil, newErrPrimaryKeyElementNotExist(idx, elem.Name())
+		}
+		pk = append(pk, v)
+	}
+	return newIndexKeyWith(idx, pk...)
+}
+
+// documentElementFrom returns the document element of the specified name, which is matched case-insensitively
+// as the schema elements are. An exact match is preferred, and the smallest name is chosen among the other matches
+// so that the same document always has the same value.
+func documentElementFrom(obj document.MapObject, name string) (any, bool) {
+	if v, ok := obj[name]; ok {
+		return v, true
+	}
+	var found string
+	var v any
+	ok := false
+	for k, ev := range obj {
+		if !strings.EqualFold(k, name) || (ok && found < k) {
+			continue
+		}
+		found, v, ok = k, ev, true
+	}
+	return v, ok
+}
+
+// newIndexKeyWith returns a new key from the specified index element values cast to the types of the index elements,
+// so that the documents decoded by the object coders which do not keep the Go types have the same index keys.
+func newIndexKeyWith(idx document.Index, values ...any) (Key, error) {
+	idxElems := idx.Elements()
+	typedValues := []any{}
+	for n, v := range values {
+		if v == nil || len(idxElems) <= n {
+			typedValues = append(typedValues, v)
+			continue
+		}
+		tv, err := document.NewValueForType(idxElems[n].Type(), v)
+		if err != nil {
+			return nil, errors.Join(newErrIndexElementValueInvalid(idx, idxElems[n], v), err)
+		}
+		typedValues = append(typedValues, tv)
+	}
+	return document.NewIndexKeyWith(idx, typedValues...)
+}
@@ -0,0 +1,200 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv_test
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/cybergarage/go-serix/serix/document"
+	"github.com/cybergarage/go-serix/serix/document/kv"
+	"github.com/cybergarage/go-serix/serix/plugins/document/key/composite"
+	"github.com/cybergarage/go-serix/serix/plugins/document/kv/memory"
+	"github.com/cybergarage/go-serix/serix/plugins/document/object/cbor"
+	"github.com/cybergarage/go-serix/serix/plugins/document/object/json"
+)
+
+func TestIndexer(t *testing.T) {
+	col := newTestCollection(t)
+	indexer := kv.NewIndexer(composite.NewCoder())
+
+	keys, err := indexer.IndexKeys("db", col, document.MapObject{"id": int64(1), "name": "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 {
+		t.Fatalf("%d != %d", len(keys), 1)
+	}
+	objKey, err := kv.NewObjectKeyFrom(keys[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, pk, err := objKey.SplitElements(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objKey.Type() != kv.IndexObject || objKey.Index != "name" || values[0] != "alice" || pk[0] != int64(1) {
+		t.Errorf("%v", objKey)
+	}
+
+	// Missing elements are indexed as nulls
+	keys, err = indexer.IndexKeys("db", col, document.MapObject{"id": int64(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys[0][len(keys[0])-2] != nil {
+		t.Errorf("%v", keys[0])
+	}
+
+	// Document elements are matched case-insensitively as the schema elements, preferring an exact match
+	for _, obj := range []document.MapObject{
+		{"ID": int64(1), "Name": "alice"},
+		{"id": int64(1), "name": "alice", "NAME": "bob"},
+		{"Id": int64(1), "nAme": "alice", "naMe": "bob"},
+	} {
+		keys, err := indexer.IndexKeys("db", col, obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, err := indexer.IndexKeys("db", col, document.MapObject{"id": int64(1), "name": "alice"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cmp, err := keys[0].Compare(expected[0]); err != nil || cmp != 0 {
+			t.Errorf("%v: %v != %v", obj, keys[0], expected[0])
+		}
+	}
+
+	// Documents must have their primary keys
+	if _, err := indexer.IndexKeys("db", col, document.MapObject{"name": "alice"}); !errors.Is(err, document.ErrNotExist) {
+		t.Errorf("%v", err)
+	}
+	if _, err := indexer.IndexKeys("db", col, document.MapObject{"id": "alice"}); !errors.Is(err, document.ErrInvalid) {
+		t.Errorf("%v", err)
+	}
+}
+
+func TestIndexerDiff(t *testing.T) {
+	col := newTestCollection(t)
+	indexer := kv.NewIndexer(composite.NewCoder())
+
+	alice := document.MapObject{"id": int64(1), "name": "alice"}
+	bob := document.MapObject{"id": int64(1), "name": "bob"}
+	aliceKeys, err := indexer.EncodeIndexKeys("db", col, alice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bobKeys, err := indexer.EncodeIndexKeys("db", col, bob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		oldObj  document.MapObject
+		newObj  document.MapObject
+		deletes [][]byte
+		inserts [][]byte
+	}{
+		{nil, alice, [][]byte{}, aliceKeys},
+		{alice, nil, aliceKeys, [][]byte{}},
+		{alice, alice, [][]byte{}, [][]byte{}},
+		{alice, bob, aliceKeys, bobKeys},
+		// Decoded documents may have other types of the same values
+		{document.MapObject{"id": float64(1), "name": []byte("alice")}, alice, [][]byte{}, [][]byte{}},
+	}
+	for _, test := range tests {
+		diff, err := indexer.Diff("db", col, test.oldObj, test.newObj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.EqualFunc(diff.Deletes, test.deletes, bytes.Equal) {
+			t.Errorf("%v -> %v: deletes % x != % x", test.oldObj, test.newObj, diff.Deletes, test.deletes)
+		}
+		if !slices.EqualFunc(diff.Inserts, test.inserts, bytes.Equal) {
+			t.Errorf("%v -> %v: inserts % x != % x", test.oldObj, test.newObj, diff.Inserts, test.inserts)
+		}
+	}
+}
+
+func TestDocumentStoreIndexes(t *testing.T) {
+	coders := []document.ObjectCoder{
+		cbor.NewCoder(),
+		json.NewCoder(),
+	}
+
+	col := newTestCollection(t)
+	keyCoder := composite.NewCoder()
+
+	for _, coder := range coders {
+		t.Run(coder.Name(), func(t *testing.T) {
+			kvStore := memory.NewStore()
+			store := kv.NewDocumentStore(kvStore, keyCoder, coder)
+
+			docs := map[int64]document.MapObject{}
+			ops := []func() error{
+				func() error {
+					docs[1] = document.MapObject{"id": int64(1), "name": "alice"}
+					return store.InsertDocument("db", col, docs[1])
+				},
+				func() error {
+					docs[2] = document.MapObject{"id": int64(2), "name": "bob"}
+					return store.InsertDocument("db", col, docs[2])
+				},
+				func() error {
+					docs[1] = document.MapObject{"id": int64(1), "name": "carol"}
+					return store.UpdateDocument("db", col, docs[1])
+				},
+				func() error {
+					delete(docs, 2)
+					return store.DeleteDocument("db", col, int64(2))
+				},
+			}
+
+			for _, op := range ops {
+				if err := op(); err != nil {
+					t.Fatal(err)
+				}
+
+				expected := [][]byte{}
+				for _, doc := range docs {
+					keys, err := store.Indexer().EncodeIndexKeys("db", col, doc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					expected = append(expected, keys...)
+				}
+				slices.SortFunc(expected, bytes.Compare)
+
+				begin, end, err := keyCoder.PrefixRange(kv.NewKeyWith(kv.IndexKeyHeader, document.NewKeyWith("db")))
+				if err != nil {
+					t.Fatal(err)
+				}
+				it, err := kvStore.Range(begin, end, document.Ascending)
+				if err != nil {
+					t.Fatal(err)
+				}
+				keys := [][]byte{}
+				for it.Next() {
+					keys = append(keys, it.Key())
+				}
+				if !slices.EqualFunc(keys, expected, bytes.Equal) {
+					t.Errorf("% x != % x", keys, expected)
+				}
+			}
+		})
+	}
+}
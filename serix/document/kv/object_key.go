@@ -0,0 +1,192 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+// Object keys are the document keys whose first element is the key header followed by the components
+// of the object type, so that the encoded keys of the same object type sort by their components:
+//
+//	database   - DatabaseKeyHeader, database
+//	collection - CollectionKeyHeader, database, collection
+//	document   - document key header, database, collection, primary key elements...
+//	index      - IndexKeyHeader, database, collection, index, index element values..., primary key elements...
+//
+// The document key header has the document type of the object coder which encodes the document,
+// e.g. DocumentKeyHeader for CBOR documents, so that the object coder is resolved from the key.
+
+// NewDatabaseKey returns a new key of the specified database.
+func NewDatabaseKey(db string) Key {
+	return NewKeyWith(DatabaseKeyHeader, document.NewKeyWith(db))
+}
+
+// NewCollectionKey returns a new key of the specified collection.
+func NewCollectionKey(db string, collection string) Key {
+	return NewKeyWith(CollectionKeyHeader, document.NewKeyWith(db, collection))
+}
+
+// NewDocumentKey returns a new key of the document of the specified document type which has the specified primary key.
+func NewDocumentKey(docType DocumentType, db string, collection string, pk Key) Key {
+	key := NewKeyWith(NewKeyHeaderWith(DocumentObject, docType), document.NewKeyWith(db, collection))
+	return append(key, pk.Elements()...)
+}
+
+// NewIndexKey returns a new key of the index entry which has the specified index element values and primary key.
+// Fewer values and a nil primary key are allowed to build a prefix key for range scans.
+func NewIndexKey(db string, collection string, index string, values Key, pk Key) Key {
+	key := NewKeyWith(IndexKeyHeader, document.NewKeyWith(db, collection, index))
+	key = append(key, values.Elements()...)
+	return append(key, pk.Elements()...)
+}
+
+// ObjectKey represents a structured key of a database, collection, document or index object.
+type ObjectKey struct {
+	// Header is the key header.
+	Header KeyHeader
+	// Database is the database name.
+	Database string
+	// Collection is the collection name of the collection, document and index keys.
+	Collection string
+	// Index is the index name of the index keys.
+	Index string
+	// Elements are the primary key elements of the document keys, or the index element values
+	// followed by the primary key elements of the index keys.
+	Elements Key
+}
+
+// NewObjectKeyFrom returns a new structured key from the specified key built by NewDatabaseKey,
+// NewCollectionKey, NewDocumentKey or NewIndexKey, or decoded from their encoded keys.
+func NewObjectKeyFrom(key Key) (*ObjectKey, error) {
+	if len(key) == 0 {
+		return nil, newErrKeyInvalid(key)
+	}
+	b, ok := key[0].([]byte)
+	if !ok || len(b) != len(KeyHeader{}) {
+		return nil, newErrKeyHeaderInvalid(key[0])
+	}
+	objKey := &ObjectKey{
+		Header:   NewKeyHeaderFrom(b),
+		Elements: document.NewKey(),
+	}
+
+	var components []*string
+	switch objKey.Type() {
+	case DatabaseObject:
+		components = []*string{&objKey.Database}
+	case CollectionObject:
+		components = []*string{&objKey.Database, &objKey.Collection}
+	case DocumentObject:
+		components = []*string{&objKey.Database, &objKey.Collection}
+	case IndexObject:
+		components = []*string{&objKey.Database, &objKey.Collection, &objKey.Index}
+	default:
+		return nil, newErrKeyHeaderInvalid(objKey.Header)
+	}
+
+	elems := key[1:]
+	if len(elems) < len(components) {
+		return nil, newErrKeyInvalid(key)
+	}
+	for n, component := range components {
+		// Some coders decode strings as byte slices
+		switch v := elems[n].(type) {
+		case string:
+			*component = v
+		case []byte:
+			*component = string(v)
+		default:
+			return nil, newErrKeyInvalid(key)
+		}
+	}
+	objKey.Elements = append(objKey.Elements, elems[len(components):]...)
+	if (objKey.Type() == DatabaseObject || objKey.Type() == CollectionObject) && len(objKey.Elements) != 0 {
+		return nil, newErrKeyInvalid(key)
+	}
+
+	return objKey, nil
+}
+
+// Type returns the header type.
+func (key *ObjectKey) Type() HeaderType {
+	return key.Header.Type()
+}
+
+// Version returns the header version.
+func (key *ObjectKey) Version() Version {
+	return key.Header.Version()
+}
+
+// DocumentType returns the document type of the database, collection and document keys.
+func (key *ObjectKey) DocumentType() DocumentType {
+	return key.Header.DocumentType()
+}
+
+// IndexType returns the index type of the index keys.
+func (key *ObjectKey) IndexType() IndexType {
+	return key.Header.IndexType()
+}
+
+// PrimaryKey returns the primary key of the document keys.
+func (key *ObjectKey) PrimaryKey() Key {
+	return key.Elements
+}
+
+// SplitElements returns the first n index element values and the rest primary key elements of the index keys.
+func (key *ObjectKey) SplitElements(n int) (Key, Key, error) {
+	if n < 0 || len(key.Elements) < n {
+		return nil, nil, newErrKeyElementsInvalid(key, n)
+	}
+	return key.Elements[:n], key.Elements[n:], nil
+}
+
+// Key returns the key which the structured key represents.
+func (key *ObjectKey) Key() Key {
+	kvKey := NewKeyWith(key.Header, document.NewKeyWith(key.Database))
+	switch key.Type() {
+	case CollectionObject, DocumentObject:
+		kvKey = append(kvKey, key.Collection)
+	case IndexObject:
+		kvKey = append(kvKey, key.Collection, key.Index)
+	}
+	return append(kvKey, key.Elements...)
+}
+
+// String returns the string representation of the structured key.
+func (key *ObjectKey) String() string {
+	return key.Key().String()
+}
+
+// KeyCoder represents a key coder for the object keys.
+type KeyCoder struct {
+	document.KeyCoder
+}
+
+// NewKeyCoder returns a new object key coder which encodes and decodes the keys with the specified key coder.
+func NewKeyCoder(coder document.KeyCoder) *KeyCoder {
+	return &KeyCoder{
+		KeyCoder: coder,
+	}
+}
+
+// ParseKey returns the structured key decoded from the specified encoded key.
+func (coder *KeyCoder) ParseKey(b []byte) (*ObjectKey, error) {
+	key, err := coder.DecodeKey(b)
+	if err != nil {
+		return nil, err
+	}
+	return NewObjectKeyFrom(key)
+}
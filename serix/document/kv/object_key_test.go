@@ -0,0 +1,132 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-serix/serix/document"
+	"github.com/cybergarage/go-serix/serix/plugins/document/key/composite"
+	"github.com/cybergarage/go-serix/serix/plugins/document/key/fdbtuple"
+	"github.com/cybergarage/go-serix/serix/plugins/document/key/memcomparable"
+)
+
+func TestObjectKey(t *testing.T) {
+	coders := []document.KeyCoder{
+		composite.NewCoder(),
+		fdbtuple.NewCoder(),
+		memcomparable.NewCoder(),
+	}
+
+	pk := document.NewKeyWith(int64(42))
+	values := document.NewKeyWith("alice", int64(20))
+
+	for _, coder := range coders {
+		t.Run(coder.Name(), func(t *testing.T) {
+			kvCoder := NewKeyCoder(coder)
+
+			testKeys := []struct {
+				key        Key
+				tp         HeaderType
+				collection string
+				index      string
+				elements   Key
+			}{
+				{NewDatabaseKey("db"), DatabaseObject, "", "", document.NewKey()},
+				{NewCollectionKey("db", "users"), CollectionObject, "users", "", document.NewKey()},
+				{NewDocumentKey(CBOR, "db", "users", pk), DocumentObject, "users", "", pk},
+				{NewDocumentKey(JSONGzip, "db", "users", pk), DocumentObject, "users", "", pk},
+				{NewIndexKey("db", "users", "name_age", values, pk), IndexObject, "users", "name_age", append(values, pk...)},
+			}
+
+			for _, tk := range testKeys {
+				b, err := kvCoder.EncodeKey(tk.key)
+				if err != nil {
+					t.Fatal(err)
+				}
+				objKey, err := kvCoder.ParseKey(b)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if objKey.Type() != tk.tp || objKey.Version() != V1 {
+					t.Errorf("%v: %c %v != %c %v", objKey, objKey.Type(), objKey.Version(), tk.tp, V1)
+				}
+				if objKey.Database != "db" || objKey.Collection != tk.collection || objKey.Index != tk.index {
+					t.Errorf("%v: unexpected components", objKey)
+				}
+				if !objKey.Elements.Equal(tk.elements) {
+					t.Errorf("%v != %v", objKey.Elements, tk.elements)
+				}
+				if !objKey.Key().Equal(tk.key) {
+					t.Errorf("%v != %v", objKey.Key(), tk.key)
+				}
+			}
+
+			for _, docType := range DocumentTypes() {
+				objKey, err := NewObjectKeyFrom(NewDocumentKey(docType, "db", "users", pk))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if objKey.DocumentType() != docType || !objKey.PrimaryKey().Equal(pk) {
+					t.Errorf("%v: %v %v", objKey, objKey.DocumentType(), objKey.PrimaryKey())
+				}
+			}
+
+			objKey, err := NewObjectKeyFrom(NewDocumentKey(CBOR, "db", "users", pk))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if objKey.Header != KeyHeader(DocumentKeyHeader) {
+				t.Errorf("%v != %v", objKey.Header, KeyHeader(DocumentKeyHeader))
+			}
+
+			objKey, err = NewObjectKeyFrom(NewIndexKey("db", "users", "name_age", values, pk))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if objKey.IndexType() != SecondaryIndex {
+				t.Errorf("%v != %v", objKey.IndexType(), SecondaryIndex)
+			}
+			idxValues, idxPK, err := objKey.SplitElements(len(values))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !idxValues.Equal(values) || !idxPK.Equal(pk) {
+				t.Errorf("%v %v != %v %v", idxValues, idxPK, values, pk)
+			}
+			if _, _, err := objKey.SplitElements(len(values) + len(pk) + 1); !errors.Is(err, document.ErrInvalid) {
+				t.Errorf("expected error for too many index elements: %v", err)
+			}
+		})
+	}
+}
+
+func TestInvalidObjectKey(t *testing.T) {
+	keys := []Key{
+		document.NewKey(),
+		document.NewKeyWith("db"),
+		document.NewKeyWith([]byte{'X', 0x11}, "db"),
+		NewKeyWith(DocumentKeyHeader, document.NewKeyWith("db")),
+		NewKeyWith(IndexKeyHeader, document.NewKeyWith("db", "users")),
+		NewKeyWith(DatabaseKeyHeader, document.NewKeyWith("db", "users")),
+		NewKeyWith(CollectionKeyHeader, document.NewKeyWith("db", int64(1))),
+	}
+	for _, key := range keys {
+		if _, err := NewObjectKeyFrom(key); !errors.Is(err, document.ErrInvalid) {
+			t.Errorf("%v should be invalid: %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+type queryBound struct {
+	value     any
+	inclusive bool
+}
+
+// Query represents a query over an index of a collection. The query matches the index entries whose
+// leading index elements are equal to the equality values and whose next index element is in the range
+// of the lower and upper bounds, and returns the documents in the order of the index.
+type Query struct {
+	index        document.Index
+	values       []any
+	lower        *queryBound
+	upper        *queryBound
+	order        document.Order
+	limit        int
+	continuation []byte
+}
+
+// NewQuery returns a new query over the specified index which matches all index entries in ascending order.
+func NewQuery(idx document.Index) *Query {
+	return &Query{
+		index:        idx,
+		values:       []any{},
+		lower:        nil,
+		upper:        nil,
+		order:        document.Ascending,
+		limit:        0,
+		continuation: nil,
+	}
+}
+
+// Index returns the index of the query.
+func (q *Query) Index() document.Index {
+	return q.index
+}
+
+// SetEqual sets the specified values which the leading index elements must be equal to.
+func (q *Query) SetEqual(values ...any) *Query {
+	q.values = values
+	return q
+}
+
+// EqualValues returns the values which the leading index elements must be equal to.
+func (q *Query) EqualValues() []any {
+	return q.values
+}
+
+// SetLowerBound sets the lower bound of the index element next to the equality values.
+// Null elements are not matched if the query has the lower or upper bound.
+func (q *Query) SetLowerBound(v any, inclusive bool) *Query {
+	q.lower = &queryBound{value: v, inclusive: inclusive}
+	return q
+}
+
+// SetUpperBound sets the upper bound of the index element next to the equality values.
+// Null elements are not matched if the query has the lower or upper bound.
+func (q *Query) SetUpperBound(v any, inclusive bool) *Query {
+	q.upper = &queryBound{value: v, inclusive: inclusive}
+	return q
+}
+
+// SetOrder sets the order of the documents. The descending order returns the documents in the reverse order of the index.
+func (q *Query) SetOrder(order document.Order) *Query {
+	q.order = order
+	return q
+}
+
+// Order returns the order of the documents.
+func (q *Query) Order() document.Order {
+	return q.order
+}
+
+// SetLimit sets the maximum number of the documents. Zero means no limit.
+func (q *Query) SetLimit(n int) *Query {
+	q.limit = n
+	return q
+}
+
+// Limit returns the maximum number of the documents.
+func (q *Query) Limit() int {
+	return q.limit
+}
+
+// SetContinuation sets the continuation token returned by the previous query to resume after the last returned document.
+func (q *Query) SetContinuation(token []byte) *Query {
+	q.continuation = token
+	return q
+}
+
+// Continuation returns the continuation token of the query.
+func (q *Query) Continuation() []byte {
+	return q.continuation
+}
+
+// KeyRange returns the range [begin, end) of the encoded index keys which the query matches in the specified
+// database and collection using the specified key coder. The coder must encode each element to a prefix-free byte
+// string as the composite coder does. The index keys are the document keys of the specified document type if the
+// index is the primary index. The continuation token narrows the range to the keys after the token in the order of the query.
+func (q *Query) KeyRange(coder document.KeyEncoder, docType DocumentType, db string, collection string) ([]byte, []byte, error) {
+	idxElems := q.index.Elements()
+	hasRange := q.lower != nil || q.upper != nil
+	n := len(q.values)
+	if hasRange {
+		n++
+	}
+	if len(idxElems) < n {
+		return nil, nil, newErrQueryInvalid(q)
+	}
+	for _, bound := range []*queryBound{q.lower, q.upper} {
+		if bound != nil && bound.value == nil {
+			return nil, nil, newErrQueryInvalid(q)
+		}
+	}
+
+	prefix, err := q.encodeKey(coder, docType, db, collection, q.values...)
+	if err != nil {
+		return nil, nil, err
+	}
+	begin, end := prefix, document.KeyStrinc(prefix)
+
+	if hasRange {
+		// The bounds of the descending element are reversed in the byte order
+		lower, upper := q.lower, q.upper
+		if q.index.ElementOrder(idxElems[len(q.values)].Name()) == document.Descending {
+			lower, upper = upper, lower
+		}
+
+		// Exclude the null elements which sort before or after all values in the byte order
+		bound := lower
+		if bound == nil {
+			bound = upper
+		}
+		boundKey, err := q.encodeKey(coder, docType, db, collection, q.valuesWith(bound.value)...)
+		if err != nil {
+			return nil, nil, err
+		}
+		nullKey, err := q.encodeKey(coder, docType, db, collection, q.valuesWith(nil)...)
+		if err != nil {
+			return nil, nil, err
+		}
+		if bytes.Compare(nullKey, boundKey) < 0 {
+			begin = document.KeyStrinc(nullKey)
+		} else {
+			end = nullKey
+		}
+
+		if lower != nil {
+			b, err := q.encodeKey(coder, docType, db, collection, q.valuesWith(lower.value)...)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !lower.inclusive {
+				b = document.KeyStrinc(b)
+			}
+			begin = maxKey(begin, b)
+		}
+		if upper != nil {
+			e, err := q.encodeKey(coder, docType, db, collection, q.valuesWith(upper.value)...)
+			if err != nil {
+				return nil, nil, err
+			}
+			if upper.inclusive {
+				e = document.KeyStrinc(e)
+			}
+			end = minKey(end, e)
+		}
+	}
+
+	if q.continuation != nil {
+		if q.order == document.Descending {
+			end = minKey(end, q.continuation)
+		} else {
+			begin = maxKey(begin, document.KeyNext(q.continuation))
+		}
+	}
+
+	return begin, end, nil
+}
+
+// valuesWith returns the equality values followed by the specified value of the next index element.
+func (q *Query) valuesWith(v any) []any {
+	return append(slices.Clone(q.values), v)
+}
+
+// encodeKey returns the encoded index key prefix of the specified index element values.
+func (q *Query) encodeKey(coder document.KeyEncoder, docType DocumentType, db string, collection string, values ...any) ([]byte, error) {
+	idxKey, err := newIndexKeyWith(q.index, values...)
+	if err != nil {
+		return nil, err
+	}
+	var key Key
+	if q.index.Type() == document.PrimaryIndex {
+		key = NewDocumentKey(docType, db, collection, idxKey)
+	} else {
+		key = NewIndexKey(db, collection, q.index.Name(), idxKey, nil)
+	}
+	return coder.EncodeKey(key)
+}
+
+// maxKey returns the larger begin key.
+func maxKey(a []byte, b []byte) []byte {
+	if bytes.Compare(a, b) < 0 {
+		return b
+	}
+	return a
+}
+
+// minKey returns the smaller end key, where nil means no upper bound.
+func minKey(a []byte, b []byte) []byte {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case bytes.Compare(b, a) < 0:
+		return b
+	}
+	return a
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/cybergarage/go-serix/serix/document"
+	"github.com/cybergarage/go-serix/serix/document/kv"
+	"github.com/cybergarage/go-serix/serix/plugins/document/key/composite"
+	"github.com/cybergarage/go-serix/serix/plugins/document/kv/memory"
+	"github.com/cybergarage/go-serix/serix/plugins/document/object/cbor"
+)
+
+func newQueryTestStore(t *testing.T) (*kv.DocumentStore, document.Collection) {
+	t.Helper()
+	col := document.NewCollection()
+	col.SetName("users")
+	id := document.NewElement().SetName("id").SetType(document.Int64Type)
+	name := document.NewElement().SetName("name").SetType(document.StringType)
+	age := document.NewElement().SetName("age").SetType(document.Int64Type)
+	for _, elem := range []document.Element{id, name, age} {
+		if err := col.AddElement(elem); err != nil {
+			t.Fatal(err)
+		}
+	}
+	idxes := []document.Index{
+		document.NewIndex().SetName("pk").SetType(document.PrimaryIndex).AddElement(id),
+		document.NewIndex().SetName("name_age").SetType(document.SecondaryIndex).AddElement(name).AddElement(age),
+		document.NewIndex().SetName("age_desc").SetType(document.SecondaryIndex).AddElement(age).SetElementOrder(age.Name(), document.Descending),
+	}
+	for _, idx := range idxes {
+		if err := col.AddIndex(idx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	store := kv.NewDocumentStore(memory.NewStore(), composite.NewCoder(), cbor.NewCoder())
+	docs := []document.MapObject{
+		{"id": int64(1), "name": "alice", "age": int64(30)},
+		{"id": int64(2), "name": "bob", "age": int64(20)},
+		{"id": int64(3), "name": "alice", "age": int64(20)},
+		{"id": int64(4), "name": "carol", "age": int64(40)},
+		{"id": int64(5), "name": "alice", "age": int64(25)},
+		{"id": int64(6), "name": "alice"},
+	}
+	for _, doc := range docs {
+		if err := store.InsertDocument("db", col, doc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return store, col
+}
+
+func queryIDs(store *kv.DocumentStore, col document.Collection, q *kv.Query) ([]string, []byte, error) {
+	it, err := store.Query("db", col, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	ids := []string{}
+	for it.Next() {
+		ids = append(ids, fmt.Sprint(it.Document()["id"]))
+	}
+	return ids, it.Continuation(), it.Err()
+}
+
+func TestQuery(t *testing.T) {
+	store, col := newQueryTestStore(t)
+
+	index := func(name string) document.Index {
+		idx, err := col.FindIndex(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return idx
+	}
+
+	tests := []struct {
+		name  string
+		query *kv.Query
+		ids   []string
+	}{
+		{"all", kv.NewQuery(index("pk")), []string{"1", "2", "3", "4", "5", "6"}},
+		{"primary range", kv.NewQuery(index("pk")).SetLowerBound(int64(2), false).SetUpperBound(int64(5), false), []string{"3", "4"}},
+		{"primary descending", kv.NewQuery(index("pk")).SetOrder(document.Descending).SetLimit(2), []string{"6", "5"}},
+		{"equal", kv.NewQuery(index("name_age")).SetEqual("alice"), []string{"6", "3", "5", "1"}},
+		{"equal all", kv.NewQuery(index("name_age")).SetEqual("alice", int64(25)), []string{"5"}},
+		{"equal range", kv.NewQuery(index("name_age")).SetEqual("alice").SetLowerBound(int64(20), true).SetUpperBound(int64(30), false), []string{"3", "5"}},
+		{"equal lower", kv.NewQuery(index("name_age")).SetEqual("alice").SetLowerBound(int64(20), false), []string{"5", "1"}},
+		{"equal upper", kv.NewQuery(index("name_age")).SetEqual("alice").SetUpperBound(int64(25), true), []string{"3", "5"}},
+		{"equal descending", kv.NewQuery(index("name_age")).SetEqual("alice").SetLowerBound(int64(0), true).SetOrder(document.Descending), []string{"1", "5", "3"}},
+		{"range", kv.NewQuery(index("name_age")).SetLowerBound("b", true), []string{"2", "4"}},
+		{"no match", kv.NewQuery(index("name_age")).SetEqual("dave"), []string{}},
+		{"descending element", kv.NewQuery(index("age_desc")).SetLowerBound(int64(25), true), []string{"4", "1", "5"}},
+		{"descending element upper", kv.NewQuery(index("age_desc")).SetUpperBound(int64(25), false), []string{"2", "3"}},
+		{"descending element reverse", kv.NewQuery(index("age_desc")).SetUpperBound(int64(30), true).SetOrder(document.Descending), []string{"3", "2", "5", "1"}},
+		{"descending element nulls", kv.NewQuery(index("age_desc")), []string{"4", "1", "5", "2", "3", "6"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ids, _, err := queryIDs(store, col, test.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(ids, test.ids) {
+				t.Errorf("%v != %v", ids, test.ids)
+			}
+		})
+	}
+}
+
+func TestQueryContinuation(t *testing.T) {
+	store, col := newQueryTestStore(t)
+	idx, err := col.FindIndex("name_age")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, order := range []document.Order{document.Ascending, document.Descending} {
+		t.Run(order.String(), func(t *testing.T) {
+			all, _, err := queryIDs(store, col, kv.NewQuery(idx).SetOrder(order))
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids := []string{}
+			var token []byte
+			for {
+				page, next, err := queryIDs(store, col, kv.NewQuery(idx).SetOrder(order).SetLimit(4).SetContinuation(token))
+				if err != nil {
+					t.Fatal(err)
+				}
+				ids = append(ids, page...)
+				if len(page) < 4 {
+					break
+				}
+				token = next
+			}
+			if len(all) != 6 || !slices.Equal(ids, all) {
+				t.Errorf("%v != %v", ids, all)
+			}
+		})
+	}
+}
+
+func TestInvalidQuery(t *testing.T) {
+	store, col := newQueryTestStore(t)
+	idx, err := col.FindIndex("name_age")
+	if err != nil {
+		t.Fatal(err)
+	}
+	queries := []*kv.Query{
+		kv.NewQuery(idx).SetEqual("alice", int64(20), int64(1)),
+		kv.NewQuery(idx).SetEqual("alice", int64(20)).SetLowerBound(int64(1), true),
+		kv.NewQuery(idx).SetLowerBound(nil, true),
+	}
+	for _, q := range queries {
+		if _, err := store.Query("db", col, q); !errors.Is(err, document.ErrInvalid) {
+			t.Errorf("%v", err)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+// Iterator represents an iterator over the encoded keys and values of a key range.
+type Iterator interface {
+	// Next advances the iterator to the next item, and returns false if there are no more items in the range.
+	Next() bool
+	// Key returns the encoded key of the current item.
+	Key() []byte
+	// Value returns the value of the current item.
+	Value() []byte
+}
+
+// Reader represents a reader of the encoded keys and values.
+type Reader interface {
+	// Get returns the value of the specified key, or an error wrapping document.ErrNotExist if the key does not exist.
+	Get(key []byte) ([]byte, error)
+	// Range returns an iterator over the items whose keys are in [begin, end) in the specified order.
+	// A nil begin or end means that the range is unbounded on the side.
+	Range(begin []byte, end []byte, order document.Order) (Iterator, error)
+}
+
+// Writer represents a writer of the encoded keys and values.
+type Writer interface {
+	// Set sets the specified value to the key.
+	Set(key []byte, value []byte) error
+	// Delete deletes the specified key, or returns an error wrapping document.ErrNotExist if the key does not exist.
+	Delete(key []byte) error
+}
+
+// ReadWriter represents a reader and writer of the encoded keys and values.
+type ReadWriter interface {
+	Reader
+	Writer
+}
+
+// Transaction represents a transaction of a key-value store. The transaction reads a consistent snapshot
+// of the store taken when it begins and its own writes, and its writes are invisible to the other transactions
+// until it is committed. The writes are applied to the store atomically on commit, and discarded on cancel.
+type Transaction interface {
+	ReadWriter
+	// IsReadOnly returns true if the transaction is read-only. The writes of the read-only transaction return
+	// an error wrapping document.ErrNotSupported.
+	IsReadOnly() bool
+	// Commit applies all writes of the transaction to the store atomically, and closes the transaction.
+	Commit() error
+	// Cancel discards all writes of the transaction, and closes the transaction.
+	Cancel() error
+}
+
+// Store represents a transactional key-value store which keeps the items sorted by the byte order of their encoded keys.
+type Store interface {
+	// Transact begins a new read-write transaction if the write flag is true, otherwise a new read-only transaction.
+	Transact(write bool) (Transaction, error)
+}
@@ -0,0 +1,140 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package document
+
+import (
+	"fmt"
+)
+
+// Order represents a sort order of a key element.
+type Order uint8
+
+const (
+	// Ascending represents an ascending sort order.
+	Ascending Order = 0x00
+	// Descending represents a descending sort order.
+	Descending Order = 0x01
+)
+
+// String returns the string representation of the order.
+func (order Order) String() string {
+	switch order {
+	case Ascending:
+		return "asc"
+	case Descending:
+		return "desc"
+	default:
+		return ""
+	}
+}
+
+func isOrder(v uint8) bool {
+	switch Order(v) {
+	case Ascending, Descending:
+		return true
+	}
+	return false
+}
+
+// NullOrder represents where null key elements are sorted relative to the other values.
+type NullOrder uint8
+
+const (
+	// NullsDefault sorts null elements as the lowest value, i.e. first in ascending order and last in descending order.
+	NullsDefault NullOrder = 0x00
+	// NullsFirst sorts null elements before the other values regardless of the sort order.
+	NullsFirst NullOrder = 0x01
+	// NullsLast sorts null elements after the other values regardless of the sort order.
+	NullsLast NullOrder = 0x02
+)
+
+func isNullOrder(v uint8) bool {
+	switch NullOrder(v) {
+	case NullsDefault, NullsFirst, NullsLast:
+		return true
+	}
+	return false
+}
+
+// String returns the string representation of the null order.
+func (nulls NullOrder) String() string {
+	switch nulls {
+	case NullsFirst:
+		return "nulls first"
+	case NullsLast:
+		return "nulls last"
+	default:
+		return ""
+	}
+}
+
+// OrderedValue represents a key element with an explicit sort order.
+type OrderedValue struct {
+	// Value is the key element value.
+	Value any
+	// Order is the sort order of the key element.
+	Order Order
+	// Nulls is the position of the key element when the value is null.
+	Nulls NullOrder
+}
+
+// NewOrderedValue returns a new key element with the specified sort order.
+func NewOrderedValue(v any, order Order) OrderedValue {
+	return OrderedValue{
+		Value: v,
+		Order: order,
+	}
+}
+
+// Asc returns a new key element sorted in ascending order.
+func Asc(v any) OrderedValue {
+	return NewOrderedValue(v, Ascending)
+}
+
+// Desc returns a new key element sorted in descending order.
+func Desc(v any) OrderedValue {
+	return NewOrderedValue(v, Descending)
+}
+
+// WithNulls returns a copy of the key element with the specified null order.
+func (ov OrderedValue) WithNulls(nulls NullOrder) OrderedValue {
+	ov.Nulls = nulls
+	return ov
+}
+
+// IsDescending returns true if the key element is sorted in descending order.
+func (ov OrderedValue) IsDescending() bool {
+	return ov.Order == Descending
+}
+
+// IsNullsLast returns true if the key element is sorted after the other values when the value is null.
+func (ov OrderedValue) IsNullsLast() bool {
+	switch ov.Nulls {
+	case NullsFirst:
+		return false
+	case NullsLast:
+		return true
+	default:
+		return ov.IsDescending()
+	}
+}
+
+// String returns the string representation of the key element.
+func (ov OrderedValue) String() string {
+	if ov.Nulls != NullsDefault {
+		return fmt.Sprintf("%s(%v %s)", ov.Order.String(), ov.Value, ov.Nulls.String())
+	}
+	return fmt.Sprintf("%s(%v)", ov.Order.String(), ov.Value)
+}
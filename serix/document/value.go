@@ -15,6 +15,8 @@
 package document
 
 import (
+	"time"
+
 	"github.com/cybergarage/go-safecast/safecast"
 )
 
@@ -62,6 +64,14 @@ func NewValueForType(et ElementType, av any) (any, error) {
 		var v bool
 		err := safecast.ToBool(av, &v)
 		return v, err
+	case BinaryType:
+		var v []byte
+		err := safecast.ToBytes(av, &v)
+		return v, err
+	case DatetimeType:
+		var v time.Time
+		err := safecast.ToTime(av, &v)
+		return v, err
 	}
 	return av, nil
 }
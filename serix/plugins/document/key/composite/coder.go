@@ -20,11 +20,14 @@ import (
 
 // Coder represents a CBOR erializer.
 type Coder struct {
+	*Config
 }
 
 // NewCoder returns a new CBOR erializer instance.
 func NewCoder() *Coder {
-	return &Coder{}
+	return &Coder{
+		Config: NewConfig(),
+	}
 }
 
 // Name returns the name of the coder.
@@ -34,11 +37,12 @@ func (s *Coder) Name() string {
 
 // EncodeKey returns the encoded bytes from the specified key if available, otherwise returns an error.
 func (s *Coder) EncodeKey(key document.Key) ([]byte, error) {
-	tpl, err := newTupleWith(key)
-	if err != nil {
-		return nil, err
-	}
-	return tpl.Pack()
+	return s.AppendKey(nil, key)
+}
+
+// AppendKey appends the encoded bytes of the specified key to the specified byte slice and returns the extended byte slice.
+func (s *Coder) AppendKey(dst []byte, key document.Key) ([]byte, error) {
+	return Tuple(key).AppendWith(dst, s.Config)
 }
 
 // DecodeKey returns the decoded key from the specified bytes if available, otherwise returns an error.
@@ -47,5 +51,31 @@ func (s *Coder) DecodeKey(b []byte) (document.Key, error) {
 	if err != nil {
 		return nil, err
 	}
-	return newKeyWith(tpl), nil
+	return document.Key(tpl), nil
+}
+
+// DecodeKeyPrefix returns the decoded first n elements of the specified encoded key, or all elements if the key has fewer elements.
+func (s *Coder) DecodeKeyPrefix(b []byte, n int) (document.Key, error) {
+	tpl, err := UnpackN(b, n)
+	if err != nil {
+		return nil, err
+	}
+	return document.Key(tpl), nil
+}
+
+// SkipKeyElements returns the rest of the specified encoded key after skipping the first n elements without decoding them.
+// The rest is also an encoded key, e.g. the primary key suffix of an index key.
+func (s *Coder) SkipKeyElements(b []byte, n int) ([]byte, error) {
+	return SkipN(b, n)
+}
+
+// KeyElementOffsets returns the byte offsets of all elements in the specified encoded key.
+func (s *Coder) KeyElementOffsets(b []byte) ([]int, error) {
+	return Offsets(b)
+}
+
+// PrefixRange returns the range [begin, end) of the encoded keys beginning with all elements of the specified key prefix.
+// The end is nil if the range has no upper bound.
+func (s *Coder) PrefixRange(prefix document.Key) ([]byte, []byte, error) {
+	return document.KeyPrefixRange(s, prefix)
 }
@@ -15,11 +15,140 @@
 package composite
 
 import (
+	"bytes"
+	"math"
 	"testing"
+	"time"
 
+	"github.com/cybergarage/go-serix/serix/document"
 	"github.com/cybergarage/go-serix/serixtest"
 )
 
 func TestCompositeCoder(t *testing.T) {
 	serixtest.KeyCoderSuite(t, NewCoder())
 }
+
+func TestCompositeNumericUnifiedCoder(t *testing.T) {
+	coder := NewCoder()
+	coder.SetNumericUnifiedEnabled(true)
+	serixtest.NumericKeyCoderSuite(t, coder)
+}
+
+func TestCompositeCompactIntegerCoder(t *testing.T) {
+	coder := NewCoder()
+	coder.SetCompactIntegerEnabled(true)
+	serixtest.IntegerLastKeyCoderSuite(t, coder)
+
+	sizes := []struct {
+		value any
+		size  int
+	}{
+		{int64(0), 1},
+		{int8(-1), 2},
+		{uint8(255), 2},
+		{int16(-256), 3},
+		{uint32(1 << 24), 5},
+		{int64(math.MinInt64), 9},
+		{uint64(math.MaxUint64), 9},
+	}
+	for _, s := range sizes {
+		b, err := coder.EncodeKey(document.NewKeyWith(s.value))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(b) != s.size {
+			t.Errorf("%v: % x (%d bytes) != %d bytes", s.value, b, len(b), s.size)
+		}
+	}
+}
+
+func TestCompositeCompactIntegerOrder(t *testing.T) {
+	// Compact integers sort after all other non-null types.
+	coder := NewCoder()
+	coder.SetCompactIntegerEnabled(true)
+
+	values := []any{
+		1.5,
+		"a",
+		[]byte("a"),
+		[16]byte{0x01},
+		document.NewKeyWith("a"),
+		time.Unix(0, 0),
+		time.Second,
+		int64(-1),
+		int64(0),
+		int64(1),
+	}
+
+	var prev []byte
+	for n, value := range values {
+		b, err := coder.EncodeKey(document.NewKeyWith(value))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if 0 < n && bytes.Compare(prev, b) >= 0 {
+			t.Errorf("%v (% x) should sort before %v (% x)", values[n-1], prev, value, b)
+		}
+		prev = b
+	}
+
+	// The default integers sort before strings.
+	defaultCoder := NewCoder()
+	i, err := defaultCoder.EncodeKey(document.NewKeyWith(int64(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := defaultCoder.EncodeKey(document.NewKeyWith("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Compare(i, s) >= 0 {
+		t.Errorf("% x should sort before % x", i, s)
+	}
+}
+
+func TestCompositeNegativeZeroMergedCoder(t *testing.T) {
+	coder := NewCoder()
+	coder.SetNegativeZeroMergedEnabled(true)
+	serixtest.KeyCoderSuite(t, coder)
+
+	zeros := [][]any{
+		{math.Copysign(0, -1), float64(0)},
+		{float32(math.Copysign(0, -1)), float32(0)},
+	}
+	for _, zero := range zeros {
+		neg, err := coder.EncodeKey(document.NewKeyWith(zero[0]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		pos, err := coder.EncodeKey(document.NewKeyWith(zero[1]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(neg, pos) {
+			t.Errorf("% x != % x", neg, pos)
+		}
+	}
+}
+
+func TestCompositeFloat32(t *testing.T) {
+	coder := NewCoder()
+	b, err := coder.EncodeKey(document.NewKeyWith(float32(0.1), float64(0.1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := coder.DecodeKey(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := key[0].(float32); !ok || v != float32(0.1) {
+		t.Errorf("%v (%T) != %v", key[0], key[0], float32(0.1))
+	}
+	if v, ok := key[1].(float64); !ok || v != 0.1 {
+		t.Errorf("%v (%T) != %v", key[1], key[1], 0.1)
+	}
+}
+
+func BenchmarkCompositeCoder(b *testing.B) {
+	serixtest.KeyCoderBenchmark(b, NewCoder())
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composite
+
+// Config represents a configuration for the composite coder.
+type Config struct {
+	NumericUnified     bool
+	CompactInteger     bool
+	NegativeZeroMerged bool
+}
+
+// NewConfig returns a new config instance.
+func NewConfig() *Config {
+	return &Config{
+		NumericUnified:     false,
+		CompactInteger:     false,
+		NegativeZeroMerged: false,
+	}
+}
+
+// SetNumericUnifiedEnabled sets a flag to encode all numeric types with one ordered representation.
+// When enabled, integers, floats and arbitrary-precision numbers share the same marker and sort
+// by their numeric values across types, and decode back as the narrowest type holding the exact value.
+func (config *Config) SetNumericUnifiedEnabled(flag bool) {
+	config.NumericUnified = flag
+}
+
+// IsNumericUnifiedEnabled returns true whether all numeric types are encoded with one ordered representation.
+func (config *Config) IsNumericUnifiedEnabled() bool {
+	return config.NumericUnified
+}
+
+// SetCompactIntegerEnabled sets a flag to encode integers with the variable-length encoding.
+// When enabled, signed and unsigned integers are encoded with the minimum number of bytes and
+// the byte length in the marker, so that small integers take only a few bytes and sort across
+// signed and unsigned types. The numeric-unified encoding takes precedence over this flag.
+func (config *Config) SetCompactIntegerEnabled(flag bool) {
+	config.CompactInteger = flag
+}
+
+// IsCompactIntegerEnabled returns true whether integers are encoded with the variable-length encoding.
+func (config *Config) IsCompactIntegerEnabled() bool {
+	return config.CompactInteger
+}
+
+// SetNegativeZeroMergedEnabled sets a flag to encode negative zero floats as positive zero.
+// When enabled, -0.0 and 0.0 have the same encoding and decode back as 0.0, otherwise -0.0
+// sorts just before 0.0 and keeps its sign. The numeric-unified encoding always merges them.
+func (config *Config) SetNegativeZeroMergedEnabled(flag bool) {
+	config.NegativeZeroMerged = flag
+}
+
+// IsNegativeZeroMergedEnabled returns true whether negative zero floats are encoded as positive zero.
+func (config *Config) IsNegativeZeroMergedEnabled() bool {
+	return config.NegativeZeroMerged
+}
@@ -0,0 +1,181 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composite
+
+import (
+	"fmt"
+
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+// Decoder decodes the elements of an encoded tuple one by one, so that the elements can be
+// read without decoding the whole tuple.
+type Decoder struct {
+	r reader
+}
+
+// NewDecoder returns a new decoder reading the specified encoded tuple.
+func NewDecoder(data []byte) *Decoder {
+	return &Decoder{
+		r: newReader(data),
+	}
+}
+
+// More returns true if the tuple has more elements to decode.
+func (dec *Decoder) More() bool {
+	return 0 < dec.r.Len()
+}
+
+// Decode decodes the next element of the tuple.
+func (dec *Decoder) Decode() (any, error) {
+	marker, err := dec.r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	return unpackOrderedElement(&dec.r, marker)
+}
+
+// Offset returns the byte offset of the next element in the encoded tuple.
+func (dec *Decoder) Offset() int {
+	return dec.r.pos
+}
+
+// Skip skips the next element of the tuple without decoding it.
+func (dec *Decoder) Skip() error {
+	marker, err := dec.r.ReadByte()
+	if err != nil {
+		return err
+	}
+	return skipOrderedElement(&dec.r, marker)
+}
+
+// UnpackN decodes the first n elements of the specified encoded tuple, or all elements if the tuple has fewer elements.
+func UnpackN(data []byte, n int) (Tuple, error) {
+	tuple := Tuple{}
+	dec := NewDecoder(data)
+	for len(tuple) < n && dec.More() {
+		elem, err := dec.Decode()
+		if err != nil {
+			return nil, err
+		}
+		tuple = append(tuple, elem)
+	}
+	return tuple, nil
+}
+
+// SkipN returns the rest of the specified encoded tuple after skipping the first n elements.
+func SkipN(data []byte, n int) ([]byte, error) {
+	dec := NewDecoder(data)
+	for i := range n {
+		if !dec.More() {
+			return nil, fmt.Errorf("tuple has only %d elements: %w", i, document.ErrInvalid)
+		}
+		if err := dec.Skip(); err != nil {
+			return nil, err
+		}
+	}
+	return data[dec.Offset():], nil
+}
+
+// Offsets returns the byte offsets of all elements in the specified encoded tuple.
+// The element n is data[offsets[n]:offsets[n+1]], and the last element ends at len(data).
+func Offsets(data []byte) ([]int, error) {
+	offsets := []int{}
+	dec := NewDecoder(data)
+	for dec.More() {
+		offsets = append(offsets, dec.Offset())
+		if err := dec.Skip(); err != nil {
+			return nil, err
+		}
+	}
+	return offsets, nil
+}
+
+func skipOrderedElement(r *reader, marker byte) error {
+	if marker&markerDescendingMask == 0 {
+		return skipElement(r, marker)
+	}
+	r.invert()
+	err := skipElement(r, ^marker)
+	r.invert()
+	return err
+}
+
+// skipElement skips the element of the specified marker without allocation.
+func skipElement(r *reader, marker byte) error {
+	if markerCompactIntMin <= marker && marker <= markerCompactIntMax {
+		n := int(marker) - int(markerCompactIntZero)
+		return r.Skip(max(n, -n))
+	}
+	switch marker {
+	case markerNull, markerNullLast, markerTrue, markerFalse:
+		return nil
+	case markerInt, markerUint, markerFloat, markerDuration:
+		return r.Skip(8)
+	case markerFloat32:
+		return r.Skip(4)
+	case markerTime:
+		return r.Skip(12)
+	case markerUUID:
+		return r.Skip(16)
+	case markerString, markerSortableBytes:
+		_, _, err := r.scanEscaped()
+		return err
+	case markerBytes:
+		length, err := r.ReadUint32()
+		if err != nil {
+			return err
+		}
+		return r.Skip(int(length))
+	case markerFixedBytes:
+		width, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		return r.Skip(int(width))
+	case markerTuple:
+		return skipNested(r)
+	case markerNumber, markerBigInt, markerBigFloat, markerDecimal:
+		return skipNumber(r)
+	default:
+		return fmt.Errorf("unknown marker: %02x", marker)
+	}
+}
+
+func skipNested(r *reader) error {
+	for {
+		marker, err := r.ReadByte()
+		if err != nil {
+			return fmt.Errorf("unexpected end while reading nested tuple")
+		}
+		if marker == tupleNullByte {
+			next, err := r.ReadByte()
+			if err != nil {
+				return fmt.Errorf("unexpected end after nested null byte")
+			}
+			switch next {
+			case tupleNullNext:
+				continue
+			case tupleTermNext:
+				return nil
+			default:
+				return fmt.Errorf("invalid nested tuple sequence: 0x00 0x%02x", next)
+			}
+		}
+		if err := skipOrderedElement(r, marker); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composite
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Floats are encoded as their IEEE 754 bits with the sign bit flipped for positive values and
+// all bits flipped for negative values, so that the encoded bytes sort in numeric order:
+//
+//	float32 - markerFloat32 followed by 4 bytes
+//	float64 - markerFloat followed by 8 bytes
+//
+// Floats are canonicalized before encoded: all NaN values, including negative and signaling ones,
+// are encoded as the quiet NaN returned by math.NaN which sorts after positive infinity, and negative
+// zero sorts just before positive zero unless the negative-zero-merged configuration is enabled.
+
+var (
+	canonicalFloat32NaN = math.Float32bits(float32(math.NaN()))
+	canonicalFloat64NaN = math.Float64bits(math.NaN())
+)
+
+func appendFloat32(dst []byte, v float32, config *Config) []byte {
+	bits := math.Float32bits(v)
+	switch {
+	case math.IsNaN(float64(v)):
+		bits = canonicalFloat32NaN
+	case v == 0 && config.IsNegativeZeroMergedEnabled():
+		bits = 0
+	}
+	if bits&(1<<31) != 0 {
+		bits = ^bits
+	} else {
+		bits ^= (1 << 31)
+	}
+	dst = append(dst, markerFloat32)
+	return binary.BigEndian.AppendUint32(dst, bits)
+}
+
+func appendFloat64(dst []byte, v float64, config *Config) []byte {
+	bits := math.Float64bits(v)
+	switch {
+	case math.IsNaN(v):
+		bits = canonicalFloat64NaN
+	case v == 0 && config.IsNegativeZeroMergedEnabled():
+		bits = 0
+	}
+	// Sortable float encoding: transform IEEE754 bits to be bytewise sortable
+	if bits&(1<<63) != 0 {
+		// Negative: flip all bits
+		bits = ^bits
+	} else {
+		// Positive: flip only sign bit
+		bits ^= (1 << 63)
+	}
+	dst = append(dst, markerFloat)
+	return binary.BigEndian.AppendUint64(dst, bits)
+}
+
+func unpackFloat32(r *reader) (float32, error) {
+	sortable, err := r.ReadUint32()
+	if err != nil {
+		return 0, err
+	}
+	if sortable&(1<<31) != 0 {
+		return math.Float32frombits(sortable ^ (1 << 31)), nil
+	}
+	return math.Float32frombits(^sortable), nil
+}
+
+func unpackFloat64(r *reader) (float64, error) {
+	sortable, err := r.ReadUint64()
+	if err != nil {
+		return 0, err
+	}
+	// Reverse sortable float encoding
+	if sortable&(1<<63) != 0 {
+		// Was positive: flip only sign bit
+		return math.Float64frombits(sortable ^ (1 << 63)), nil
+	}
+	// Was negative: flip all bits
+	return math.Float64frombits(^sortable), nil
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composite
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// Compact integers are encoded as a marker which has the byte length of the magnitude followed by
+// the big-endian magnitude bytes without leading zeros, like the FoundationDB tuple layer:
+//
+//	zero     - markerCompactIntZero
+//	positive - markerCompactIntZero + n, followed by n bytes of the value
+//	negative - markerCompactIntZero - n, followed by n bytes of the ones' complement of the magnitude
+//
+// Longer positive values sort after shorter ones, and longer negative values sort before shorter ones.
+
+func appendCompactInt(dst []byte, v int64) []byte {
+	if v < 0 {
+		// The magnitude of math.MinInt64 fits in uint64
+		return appendCompactUint(dst, uint64(-(v+1))+1, true)
+	}
+	return appendCompactUint(dst, uint64(v), false)
+}
+
+func appendCompactUint(dst []byte, mag uint64, neg bool) []byte {
+	n := (bits.Len64(mag) + 7) / 8
+	if neg {
+		dst = append(dst, markerCompactIntZero-byte(n))
+		mag = ^mag
+	} else {
+		dst = append(dst, markerCompactIntZero+byte(n))
+	}
+	for i := n - 1; 0 <= i; i-- {
+		dst = append(dst, byte(mag>>(8*i)))
+	}
+	return dst
+}
+
+func unpackCompactInt(r *reader, marker byte) (any, error) {
+	if marker == markerCompactIntZero {
+		return int64(0), nil
+	}
+	neg := marker < markerCompactIntZero
+	n := int(marker) - int(markerCompactIntZero)
+	if neg {
+		n = -n
+	}
+	var data [8]byte
+	if err := r.ReadFull(data[8-n:]); err != nil {
+		return nil, err
+	}
+	var mag uint64
+	for _, b := range data[8-n:] {
+		mag = mag<<8 | uint64(b)
+	}
+	if !neg {
+		if mag <= math.MaxInt64 {
+			return int64(mag), nil
+		}
+		return mag, nil
+	}
+	// Reverse the ones' complement of the magnitude
+	mag = ^mag & (math.MaxUint64 >> (64 - 8*n))
+	if mag == 0 || uint64(math.MaxInt64)+1 < mag {
+		return nil, fmt.Errorf("invalid compact integer: %d bytes negative magnitude %d", n, mag)
+	}
+	return -int64(mag-1) - 1, nil
+}
@@ -0,0 +1,384 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composite
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/cybergarage/go-safecast/safecast"
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+// Arbitrary-precision numbers are encoded as a sign class followed by a decimal exponent and
+// significant digits, so that the encoded bytes sort in numeric order:
+//
+//	class    - 1 byte (negative infinity, negative, zero, positive, positive infinity, NaN)
+//	exponent - 8 bytes sortable int64, the value is 0.d1d2d3... * 10^exponent
+//	digits   - ASCII decimal digits without leading and trailing zeros, terminated by 0x00
+//
+// The exponent and digits of negative numbers are bitwise complemented to reverse their order.
+// The exponent must not differ from the number of digits by more than document.DecimalMaxScale, so that
+// the numbers decoded from corrupt keys cannot expand to huge integers or decimals.
+
+const (
+	numberNegInf   byte = 0x00
+	numberNegative byte = 0x01
+	numberZero     byte = 0x02
+	numberPositive byte = 0x03
+	numberPosInf   byte = 0x04
+	numberNaN      byte = 0x05
+)
+
+const (
+	numberDigitsTerminator byte = 0x00
+)
+
+// number represents a normalized decimal number, 0.digits * 10^exp.
+type number struct {
+	neg    bool
+	nan    bool
+	inf    int
+	exp    int64
+	digits string
+}
+
+func newNumberWithDigits(neg bool, digits string, exp int64) number {
+	trimmed := strings.TrimLeft(digits, "0")
+	exp -= int64(len(digits) - len(trimmed))
+	trimmed = strings.TrimRight(trimmed, "0")
+	if trimmed == "" {
+		return number{}
+	}
+	return number{
+		neg:    neg,
+		exp:    exp,
+		digits: trimmed,
+	}
+}
+
+func newNumberFromBigInt(v *big.Int) number {
+	digits := new(big.Int).Abs(v).String()
+	return newNumberWithDigits(v.Sign() < 0, digits, int64(len(digits)))
+}
+
+func newNumberFromDecimal(v document.Decimal) number {
+	unscaled := v.Unscaled()
+	digits := new(big.Int).Abs(unscaled).String()
+	return newNumberWithDigits(unscaled.Sign() < 0, digits, int64(len(digits))-int64(v.Scale()))
+}
+
+func newNumberFromBigFloat(v *big.Float) number {
+	if v.IsInf() {
+		return number{inf: v.Sign()}
+	}
+	// Finite big floats are always exact decimals
+	dec, _ := document.NewDecimalFromBigFloat(v)
+	return newNumberFromDecimal(dec)
+}
+
+// newNumberFromValue returns the number of the specified value if the value is a numeric type.
+// Floats are expanded to their exact decimal values so that they compare exactly with other numbers.
+func newNumberFromValue(v any) (number, bool) {
+	switch v := v.(type) {
+	case int, int8, int16, int32, int64:
+		var iv int64
+		if err := safecast.ToInt64(v, &iv); err != nil {
+			return number{}, false
+		}
+		return newNumberFromBigInt(big.NewInt(iv)), true
+	case uint, uint8, uint16, uint32, uint64:
+		var uv uint64
+		if err := safecast.ToUint64(v, &uv); err != nil {
+			return number{}, false
+		}
+		return newNumberFromBigInt(new(big.Int).SetUint64(uv)), true
+	case float32:
+		return newNumberFromFloat64(float64(v)), true
+	case float64:
+		return newNumberFromFloat64(v), true
+	case *big.Int:
+		if v == nil {
+			return number{}, false
+		}
+		return newNumberFromBigInt(v), true
+	case *big.Float:
+		if v == nil {
+			return number{}, false
+		}
+		return newNumberFromBigFloat(v), true
+	case document.Decimal:
+		return newNumberFromDecimal(v), true
+	}
+	return number{}, false
+}
+
+func newNumberFromFloat64(v float64) number {
+	if math.IsNaN(v) {
+		return number{nan: true}
+	}
+	return newNumberFromBigFloat(big.NewFloat(v))
+}
+
+// validate returns an error if the exponent is out of the range of the digits.
+func (n number) validate() error {
+	if n.digits == "" {
+		return nil
+	}
+	ndigits := int64(len(n.digits))
+	if n.exp < ndigits-document.DecimalMaxScale || ndigits+document.DecimalMaxScale < n.exp {
+		return fmt.Errorf("number exponent %d is out of range for %d digits", n.exp, ndigits)
+	}
+	return nil
+}
+
+// String returns the scientific representation of the number.
+func (n number) String() string {
+	switch {
+	case n.nan:
+		return "NaN"
+	case n.inf < 0:
+		return "-Inf"
+	case 0 < n.inf:
+		return "+Inf"
+	case n.digits == "":
+		return "0"
+	case n.neg:
+		return fmt.Sprintf("-0.%se%d", n.digits, n.exp)
+	default:
+		return fmt.Sprintf("0.%se%d", n.digits, n.exp)
+	}
+}
+
+func (n number) class() byte {
+	switch {
+	case n.nan:
+		return numberNaN
+	case n.inf < 0:
+		return numberNegInf
+	case 0 < n.inf:
+		return numberPosInf
+	case n.digits == "":
+		return numberZero
+	case n.neg:
+		return numberNegative
+	default:
+		return numberPositive
+	}
+}
+
+// BigInt returns the number as a big integer if the number is an integer.
+func (n number) BigInt() (*big.Int, error) {
+	if n.inf != 0 || (n.digits != "" && n.exp < int64(len(n.digits))) {
+		return nil, fmt.Errorf("number (%s) is not an integer", n)
+	}
+	if n.digits == "" {
+		return new(big.Int), nil
+	}
+	if err := n.validate(); err != nil {
+		return nil, err
+	}
+	v, _ := new(big.Int).SetString(n.digits, 10)
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(n.exp-int64(len(n.digits))), nil)
+	v.Mul(v, pow)
+	if n.neg {
+		v.Neg(v)
+	}
+	return v, nil
+}
+
+// Decimal returns the number as a decimal.
+func (n number) Decimal() (document.Decimal, error) {
+	if n.inf != 0 || n.nan {
+		return document.Decimal{}, fmt.Errorf("number (%s) is not a decimal", n)
+	}
+	if n.digits == "" {
+		return document.NewDecimal(new(big.Int), 0), nil
+	}
+	if err := n.validate(); err != nil {
+		return document.Decimal{}, err
+	}
+	unscaled, _ := new(big.Int).SetString(n.digits, 10)
+	if n.neg {
+		unscaled.Neg(unscaled)
+	}
+	scale := int64(len(n.digits)) - n.exp
+	if scale < 0 {
+		pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(-scale), nil)
+		return document.NewDecimal(unscaled.Mul(unscaled, pow), 0), nil
+	}
+	var scale32 int32
+	if err := safecast.ToInt32(scale, &scale32); err != nil {
+		return document.Decimal{}, err
+	}
+	return document.NewDecimal(unscaled, scale32), nil
+}
+
+// BigFloat returns the number as a big float with enough precision to hold the digits exactly.
+func (n number) BigFloat() (*big.Float, error) {
+	if n.inf != 0 {
+		return new(big.Float).SetInf(n.inf < 0), nil
+	}
+	dec, err := n.Decimal()
+	if err != nil {
+		return nil, err
+	}
+	prec := max(64, uint(4*len(n.digits)))
+	return new(big.Float).SetPrec(prec).SetRat(dec.Rat()), nil
+}
+
+// Value returns the number as the narrowest type holding the exact value: int64, uint64 or *big.Int
+// for integers, float64 for exactly representable fractions and document.Decimal for the others.
+func (n number) Value() (any, error) {
+	switch {
+	case n.nan:
+		return math.NaN(), nil
+	case n.inf != 0:
+		return math.Inf(n.inf), nil
+	case n.digits == "":
+		return int64(0), nil
+	}
+	if int64(len(n.digits)) <= n.exp {
+		v, err := n.BigInt()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case v.IsInt64():
+			return v.Int64(), nil
+		case v.IsUint64():
+			return v.Uint64(), nil
+		default:
+			return v, nil
+		}
+	}
+	dec, err := n.Decimal()
+	if err != nil {
+		return nil, err
+	}
+	if f, exact := dec.Rat().Float64(); exact {
+		return f, nil
+	}
+	return dec, nil
+}
+
+// appendNumber appends the specified number using the sortable number encoding.
+func appendNumber(dst []byte, n number) ([]byte, error) {
+	if err := n.validate(); err != nil {
+		return nil, err
+	}
+	class := n.class()
+	dst = append(dst, class)
+	if class != numberNegative && class != numberPositive {
+		return dst, nil
+	}
+	start := len(dst)
+	dst = binary.BigEndian.AppendUint64(dst, uint64(n.exp)^(1<<63))
+	dst = append(dst, n.digits...)
+	dst = append(dst, numberDigitsTerminator)
+	if n.neg {
+		invertBytes(dst[start:])
+	}
+	return dst, nil
+}
+
+// unpackNumber reads the number written by appendNumber.
+func unpackNumber(r *reader) (number, error) {
+	class, err := r.ReadByte()
+	if err != nil {
+		return number{}, err
+	}
+	switch class {
+	case numberNegInf:
+		return number{inf: -1}, nil
+	case numberPosInf:
+		return number{inf: 1}, nil
+	case numberNaN:
+		return number{nan: true}, nil
+	case numberZero:
+		return number{}, nil
+	case numberNegative, numberPositive:
+	default:
+		return number{}, fmt.Errorf("invalid number class: %02x", class)
+	}
+
+	if class == numberNegative {
+		r.invert()
+		defer r.invert()
+	}
+
+	sortable, err := r.ReadUint64()
+	if err != nil {
+		return number{}, err
+	}
+	var digits strings.Builder
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return number{}, fmt.Errorf("unexpected end while reading number digits")
+		}
+		if b == numberDigitsTerminator {
+			break
+		}
+		if b < '0' || '9' < b {
+			return number{}, fmt.Errorf("invalid number digit: %02x", b)
+		}
+		digits.WriteByte(b)
+	}
+
+	n := number{
+		neg:    class == numberNegative,
+		exp:    int64(sortable ^ (1 << 63)),
+		digits: digits.String(),
+	}
+	if err := n.validate(); err != nil {
+		return number{}, err
+	}
+	return n, nil
+}
+
+// skipNumber skips the number written by appendNumber.
+func skipNumber(r *reader) error {
+	class, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+	switch class {
+	case numberNegInf, numberPosInf, numberNaN, numberZero:
+		return nil
+	case numberNegative, numberPositive:
+	default:
+		return fmt.Errorf("invalid number class: %02x", class)
+	}
+	if class == numberNegative {
+		r.invert()
+		defer r.invert()
+	}
+	if err := r.Skip(8); err != nil {
+		return err
+	}
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return fmt.Errorf("unexpected end while reading number digits")
+		}
+		if b == numberDigitsTerminator {
+			return nil
+		}
+	}
+}
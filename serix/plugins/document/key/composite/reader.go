@@ -0,0 +1,169 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composite
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// reader reads the encoded element bytes from a byte slice without copying them.
+// The bytes are complemented while the reader is inverted to decode descending elements.
+type reader struct {
+	data []byte
+	pos  int
+	mask byte
+}
+
+func newReader(data []byte) reader {
+	return reader{
+		data: data,
+		pos:  0,
+		mask: 0x00,
+	}
+}
+
+// invert toggles the complement of the bytes to read.
+func (r *reader) invert() {
+	r.mask ^= 0xFF
+}
+
+// Len returns the number of the unread bytes.
+func (r *reader) Len() int {
+	return len(r.data) - r.pos
+}
+
+// ReadByte reads a byte.
+func (r *reader) ReadByte() (byte, error) {
+	if len(r.data) <= r.pos {
+		return 0, io.ErrUnexpectedEOF
+	}
+	b := r.data[r.pos] ^ r.mask
+	r.pos++
+	return b, nil
+}
+
+// ReadFull reads exactly len(p) bytes into the specified buffer.
+func (r *reader) ReadFull(p []byte) error {
+	if r.Len() < len(p) {
+		return io.ErrUnexpectedEOF
+	}
+	for n := range p {
+		p[n] = r.data[r.pos+n] ^ r.mask
+	}
+	r.pos += len(p)
+	return nil
+}
+
+// Skip skips the specified number of bytes.
+func (r *reader) Skip(n int) error {
+	if r.Len() < n {
+		return io.ErrUnexpectedEOF
+	}
+	r.pos += n
+	return nil
+}
+
+// ReadUint64 reads a big-endian uint64.
+func (r *reader) ReadUint64() (uint64, error) {
+	if r.Len() < 8 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	v := binary.BigEndian.Uint64(r.data[r.pos:])
+	if r.mask != 0 {
+		v = ^v
+	}
+	r.pos += 8
+	return v, nil
+}
+
+// ReadUint32 reads a big-endian uint32.
+func (r *reader) ReadUint32() (uint32, error) {
+	if r.Len() < 4 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	v := binary.BigEndian.Uint32(r.data[r.pos:])
+	if r.mask != 0 {
+		v = ^v
+	}
+	r.pos += 4
+	return v, nil
+}
+
+// scanEscaped reads the raw bytes encoded by appendEscaped without the terminator,
+// and returns them with the number of the unescaped bytes.
+func (r *reader) scanEscaped() ([]byte, int, error) {
+	start := r.pos
+	n := 0
+	for i := r.pos; i < len(r.data); i++ {
+		if r.data[i]^r.mask != stringEscapeByte {
+			n++
+			continue
+		}
+		i++
+		if len(r.data) <= i {
+			return nil, 0, fmt.Errorf("unexpected end after escape byte")
+		}
+		switch r.data[i] ^ r.mask {
+		case stringEscapeNext:
+			// Escaped 0x00
+			n++
+		case stringTermNext:
+			// Found terminator 0x00 0x00
+			r.pos = i + 1
+			return r.data[start : i-1], n, nil
+		default:
+			return nil, 0, fmt.Errorf("invalid escape sequence: 0x00 0x%02x", r.data[i]^r.mask)
+		}
+	}
+	return nil, 0, fmt.Errorf("unexpected end while reading string")
+}
+
+// unescape returns the unescaped bytes of the specified raw bytes read by scanEscaped.
+func (r *reader) unescape(raw []byte, n int) []byte {
+	data := make([]byte, 0, n)
+	for i := 0; i < len(raw); i++ {
+		b := raw[i] ^ r.mask
+		data = append(data, b)
+		if b == stringEscapeByte {
+			// Skip the escape byte
+			i++
+		}
+	}
+	return data
+}
+
+// ReadEscaped reads the bytes encoded by appendEscaped.
+func (r *reader) ReadEscaped() ([]byte, error) {
+	raw, n, err := r.scanEscaped()
+	if err != nil {
+		return nil, err
+	}
+	return r.unescape(raw, n), nil
+}
+
+// ReadEscapedString reads the string encoded by appendEscaped.
+func (r *reader) ReadEscapedString() (string, error) {
+	raw, n, err := r.scanEscaped()
+	if err != nil {
+		return "", err
+	}
+	if n == len(raw) && r.mask == 0 {
+		// No escaped bytes, the raw bytes are the string itself
+		return string(raw), nil
+	}
+	return string(r.unescape(raw, n)), nil
+}
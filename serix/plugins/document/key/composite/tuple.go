@@ -15,12 +15,13 @@
 package composite
 
 import (
-	"bytes"
 	"encoding/binary"
 	"fmt"
 	"math"
+	"math/big"
+	"reflect"
+	"time"
 
-	"github.com/cybergarage/go-safecast/safecast"
 	"github.com/cybergarage/go-serix/serix/document"
 )
 
@@ -29,17 +30,75 @@ import (
 // COMPATIBILITY WARNING: The encoding format for int, float, and string types has been
 // updated to support bytewise-sortable ordering for RocksDB. Keys encoded with the previous
 // version are NOT compatible with this version and will not sort correctly.
+//
+// Byte slices are encoded with the same escaping scheme as strings under a new marker, so they sort
+// lexicographically. Keys with the previous length-prefixed byte encoding can still be decoded.
+//
+// Elements wrapped by document.Desc are encoded as the bitwise complement of their ascending
+// encoding, so they sort in reverse order and decode back as document.OrderedValue.
+// Null elements sort first in ascending order and last in descending order by default, and null
+// elements with an explicit null order which differs from the default are encoded with the highest
+// marker before complemented, decoding back as document.OrderedValue with the null order.
+// Elements of type float32 keep their width under a separate marker, and floats are canonicalized so that
+// all NaN values sort after positive infinity; negative zero sorts before positive zero by default.
+// Elements of type time.Time are normalized to UTC with nanosecond precision, and time.Duration
+// elements keep their type; both sort chronologically including dates before 1970.
+// Elements of type *big.Int, *big.Float and document.Decimal are encoded with a variable-length
+// sign, exponent and digits encoding which sorts in numeric order within each type.
+// With the compact integer configuration, integers are encoded with a variable-length encoding
+// which has the byte length in the marker, like the FoundationDB tuple layer. The compact integer
+// markers are above the time markers, so compact integers sort after floats, strings, byte strings,
+// nested tuples, times and durations, unlike the default integers which sort before them.
+// With the numeric-unified configuration, all numeric elements share the same variable-length
+// encoding so that the encoded order across numeric types matches document.Key.Compare.
+// Fixed-length byte arrays such as UUIDs are encoded without a length prefix and decode back
+// as byte arrays of the same width, e.g. [16]byte, keeping their byte order.
+// Elements of type document.Key or []any are encoded as nested tuples and decode back as document.Key.
 type Tuple []any
 
 const (
-	markerNull   byte = 0x00
-	markerTrue   byte = 0x01
-	markerFalse  byte = 0x02
-	markerInt    byte = 0x10
-	markerUint   byte = 0x11
-	markerFloat  byte = 0x20
-	markerString byte = 0x30
-	markerBytes  byte = 0x40
+	markerNull     byte = 0x00
+	markerTrue     byte = 0x01
+	markerFalse    byte = 0x02
+	markerInt      byte = 0x10
+	markerUint     byte = 0x11
+	markerBigInt   byte = 0x12
+	markerNumber   byte = 0x18
+	markerFloat32  byte = 0x1F
+	markerFloat    byte = 0x20
+	markerBigFloat byte = 0x21
+	markerDecimal  byte = 0x22
+	markerString   byte = 0x30
+	// markerBytes is the length-prefixed byte encoding which is kept only for decoding existing keys.
+	markerBytes         byte = 0x40
+	markerSortableBytes byte = 0x41
+	markerUUID          byte = 0x42
+	markerFixedBytes    byte = 0x43
+	markerTuple         byte = 0x50
+	markerTime          byte = 0x60
+	markerDuration      byte = 0x61
+	// Compact integers encode the byte length in the marker: 0x68 (8 bytes negative) to 0x78 (8 bytes positive).
+	// No free range below markerString has room for the 17 markers, so compact integers sort after all other
+	// types except markerNullLast.
+	markerCompactIntZero byte = 0x70
+	markerCompactIntMin  byte = markerCompactIntZero - 8
+	markerCompactIntMax  byte = markerCompactIntZero + 8
+	// markerNullLast is the null marker sorted after all other markers for the nulls last order.
+	markerNullLast byte = 0x7F
+)
+
+const (
+	// Nested tuple encoding escapes null elements and terminates with 0x00 0x00.
+	tupleNullByte   byte = 0x00
+	tupleNullNext   byte = 0xFF
+	tupleTerminator byte = 0x00
+	tupleTermNext   byte = 0x00
+)
+
+const (
+	// Descending elements are encoded as the bitwise complement of their ascending encoding.
+	// All ascending markers are below 0x80, so the complemented markers never collide with them.
+	markerDescendingMask byte = 0x80
 )
 
 const (
@@ -53,213 +112,375 @@ const (
 // Pack encodes the tuple into a byte slice using sortable encoding.
 // Int, float, and string types are encoded to be bytewise-sortable for RocksDB.
 func (t Tuple) Pack() ([]byte, error) {
-	packed, err := t.packSimple()
-	if err != nil {
-		return nil, err
-	}
-	return packed, nil
+	return t.PackWith(NewConfig())
 }
 
-func (t Tuple) packSimple() ([]byte, error) {
-	var buf bytes.Buffer
+// PackWith encodes the tuple into a byte slice using sortable encoding with the specified configuration.
+func (t Tuple) PackWith(config *Config) ([]byte, error) {
+	return t.AppendWith(nil, config)
+}
 
+// AppendWith appends the encoded tuple to the specified byte slice using sortable encoding with the specified
+// configuration, and returns the extended byte slice. The tuple is encoded without any allocation if the byte
+// slice has enough capacity, except for the arbitrary-precision numbers.
+func (t Tuple) AppendWith(dst []byte, config *Config) ([]byte, error) {
+	var err error
 	for _, elem := range t {
-		switch v := elem.(type) {
-		case nil:
-			buf.WriteByte(markerNull)
-		case bool:
-			if v {
-				buf.WriteByte(markerTrue)
-			} else {
-				buf.WriteByte(markerFalse)
-			}
-		case int, int8, int16, int32, int64:
-			buf.WriteByte(markerInt)
-			var tv int64
-			if err := safecast.ToInt64(v, &tv); err != nil {
-				return nil, err
-			}
-			// Sortable int encoding: flip sign bit to make negative values sort before positive
-			sortable := uint64(tv) ^ (1 << 63)
-			binary.Write(&buf, binary.BigEndian, sortable)
-		case uint, uint8, uint16, uint32, uint64:
-			buf.WriteByte(markerUint)
-			var tv uint64
-			if err := safecast.ToUint64(v, &tv); err != nil {
-				return nil, err
-			}
-			binary.Write(&buf, binary.BigEndian, tv)
-		case float32, float64:
-			buf.WriteByte(markerFloat)
-			var tv float64
-			if err := safecast.ToFloat64(v, &tv); err != nil {
+		dst, err = appendElement(dst, elem, config)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return dst, nil
+}
+
+// nolint: gocyclo, maintidx
+func appendElement(dst []byte, elem any, config *Config) ([]byte, error) {
+	if config.IsNumericUnifiedEnabled() {
+		if n, ok := newNumberFromValue(elem); ok {
+			dst = append(dst, markerNumber)
+			return appendNumber(dst, n)
+		}
+	}
+	switch v := elem.(type) {
+	case document.OrderedValue:
+		start := len(dst)
+		var err error
+		if v.Value == nil {
+			dst = append(dst, nullMarker(v))
+		} else {
+			dst, err = appendElement(dst, v.Value, config)
+			if err != nil {
 				return nil, err
 			}
-			// Sortable float encoding: transform IEEE754 bits to be bytewise sortable
-			bits := math.Float64bits(tv)
-			if bits&(1<<63) != 0 {
-				// Negative: flip all bits
-				bits = ^bits
-			} else {
-				// Positive: flip only sign bit
-				bits ^= (1 << 63)
-			}
-			binary.Write(&buf, binary.BigEndian, bits)
-		case string:
-			buf.WriteByte(markerString)
-			data := []byte(v)
-			// Sortable string encoding: escape 0x00 bytes and terminate with 0x00 0x00
-			for _, b := range data {
-				if b == stringEscapeByte {
-					buf.WriteByte(stringEscapeByte)
-					buf.WriteByte(stringEscapeNext)
-				} else {
-					buf.WriteByte(b)
-				}
-			}
-			// Terminator
-			buf.WriteByte(stringTerminator)
-			buf.WriteByte(stringTermNext)
-		case []byte:
-			buf.WriteByte(markerBytes)
-			binary.Write(&buf, binary.BigEndian, uint32(len(v)))
-			buf.Write(v)
-		default:
-			// Convert unknown types to strings
-			str := fmt.Sprintf("%v", v)
-			buf.WriteByte(markerString)
-			data := []byte(str)
-			// Sortable string encoding: escape 0x00 bytes and terminate with 0x00 0x00
-			for _, b := range data {
-				if b == stringEscapeByte {
-					buf.WriteByte(stringEscapeByte)
-					buf.WriteByte(stringEscapeNext)
-				} else {
-					buf.WriteByte(b)
-				}
-			}
-			// Terminator
-			buf.WriteByte(stringTerminator)
-			buf.WriteByte(stringTermNext)
 		}
+		if v.IsDescending() {
+			invertBytes(dst[start:])
+		}
+		return dst, nil
+	case nil:
+		return append(dst, markerNull), nil
+	case bool:
+		if v {
+			return append(dst, markerTrue), nil
+		}
+		return append(dst, markerFalse), nil
+	case time.Time:
+		// Sortable time encoding: UTC seconds since epoch as a sortable int and nanoseconds
+		dst = append(dst, markerTime)
+		dst = binary.BigEndian.AppendUint64(dst, uint64(v.Unix())^(1<<63))
+		return binary.BigEndian.AppendUint32(dst, uint32(v.Nanosecond())), nil
+	case time.Duration:
+		dst = append(dst, markerDuration)
+		return binary.BigEndian.AppendUint64(dst, uint64(v)^(1<<63)), nil
+	case int, int8, int16, int32, int64:
+		tv, _ := document.Int64From(v)
+		if config.IsCompactIntegerEnabled() {
+			return appendCompactInt(dst, tv), nil
+		}
+		dst = append(dst, markerInt)
+		// Sortable int encoding: flip sign bit to make negative values sort before positive
+		sortable := uint64(tv) ^ (1 << 63)
+		return binary.BigEndian.AppendUint64(dst, sortable), nil
+	case uint, uint8, uint16, uint32, uint64:
+		tv, _ := document.Uint64From(v)
+		if config.IsCompactIntegerEnabled() {
+			return appendCompactUint(dst, tv, false), nil
+		}
+		dst = append(dst, markerUint)
+		return binary.BigEndian.AppendUint64(dst, tv), nil
+	case float32:
+		return appendFloat32(dst, v, config), nil
+	case float64:
+		return appendFloat64(dst, v, config), nil
+	case string:
+		dst = append(dst, markerString)
+		return appendEscaped(dst, v), nil
+	case []byte:
+		dst = append(dst, markerSortableBytes)
+		return appendEscaped(dst, v), nil
+	case document.Key:
+		return appendNested(dst, v, config)
+	case []any:
+		return appendNested(dst, v, config)
+	case *big.Int:
+		if v == nil {
+			return append(dst, markerNull), nil
+		}
+		dst = append(dst, markerBigInt)
+		return appendNumber(dst, newNumberFromBigInt(v))
+	case *big.Float:
+		if v == nil {
+			return append(dst, markerNull), nil
+		}
+		dst = append(dst, markerBigFloat)
+		return appendNumber(dst, newNumberFromBigFloat(v))
+	case document.Decimal:
+		dst = append(dst, markerDecimal)
+		return appendNumber(dst, newNumberFromDecimal(v))
+	case [16]byte:
+		dst = append(dst, markerUUID)
+		return append(dst, v[:]...), nil
+	default:
+		if data, ok := fixedBytesFrom(v); ok {
+			return appendFixedBytes(dst, data), nil
+		}
+		// Convert unknown types to strings
+		dst = append(dst, markerString)
+		return appendEscaped(dst, fmt.Sprintf("%v", v)), nil
 	}
-	return buf.Bytes(), nil
 }
 
-// Unpack decodes a byte slice into a tuple.
-func Unpack(data []byte) (Tuple, error) {
-	return unpackSimple(data)
+// appendEscaped appends the specified bytes using the sortable string encoding:
+// 0x00 bytes are escaped as 0x00 0xFF and the bytes are terminated with 0x00 0x00.
+func appendEscaped[T string | []byte](dst []byte, data T) []byte {
+	for n := range len(data) {
+		b := data[n]
+		if b == stringEscapeByte {
+			dst = append(dst, stringEscapeByte, stringEscapeNext)
+		} else {
+			dst = append(dst, b)
+		}
+	}
+	// Terminator
+	return append(dst, stringTerminator, stringTermNext)
 }
 
-func unpackSimple(data []byte) (Tuple, error) {
-	buf := bytes.NewBuffer(data)
-	var tuple Tuple
+// fixedBytesFrom returns the bytes of the specified value if it is a fixed-length byte array such as UUID.
+func fixedBytesFrom(v any) ([]byte, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Array || rv.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, false
+	}
+	if rv.Len() < 1 || math.MaxUint8 < rv.Len() {
+		return nil, false
+	}
+	data := make([]byte, rv.Len())
+	reflect.Copy(reflect.ValueOf(data), rv)
+	return data, true
+}
+
+// appendFixedBytes appends the specified fixed-length identifier. 16-byte identifiers are written
+// without any width, and other identifiers are prefixed by their width to group them by type.
+func appendFixedBytes(dst []byte, data []byte) []byte {
+	if len(data) == 16 {
+		dst = append(dst, markerUUID)
+		return append(dst, data...)
+	}
+	dst = append(dst, markerFixedBytes, byte(len(data)))
+	return append(dst, data...)
+}
 
+// appendNested appends the specified elements as a nested tuple.
+// Null elements are escaped as 0x00 0xFF and the tuple is terminated with 0x00 0x00,
+// so a nested tuple sorts lexicographically by its elements and before any longer tuple.
+func appendNested(dst []byte, elems []any, config *Config) ([]byte, error) {
 	var err error
-	for buf.Len() > 0 {
-		var marker byte
-		marker, err = buf.ReadByte()
+	dst = append(dst, markerTuple)
+	for _, elem := range elems {
+		if isNestedNull(elem) {
+			dst = append(dst, tupleNullByte, tupleNullNext)
+			continue
+		}
+		dst, err = appendElement(dst, elem, config)
 		if err != nil {
-			break
+			return nil, err
 		}
+	}
+	return append(dst, tupleTerminator, tupleTermNext), nil
+}
 
-		switch marker {
-		case markerNull:
-			tuple = append(tuple, nil)
-		case markerTrue:
-			tuple = append(tuple, true)
-		case markerFalse:
-			tuple = append(tuple, false)
-		case markerInt:
-			var sortable uint64
-			err := binary.Read(buf, binary.BigEndian, &sortable)
-			if err != nil {
-				return nil, err
-			}
-			// Reverse sortable int encoding: flip sign bit back
-			val := int64(sortable ^ (1 << 63))
-			tuple = append(tuple, val)
-		case markerUint:
-			var val uint64
-			err := binary.Read(buf, binary.BigEndian, &val)
-			if err != nil {
-				return nil, err
-			}
-			tuple = append(tuple, val)
-		case markerFloat:
-			var sortableBits uint64
-			err := binary.Read(buf, binary.BigEndian, &sortableBits)
-			if err != nil {
-				return nil, err
-			}
-			// Reverse sortable float encoding
-			var bits uint64
-			if sortableBits&(1<<63) != 0 {
-				// Was positive: flip only sign bit
-				bits = sortableBits ^ (1 << 63)
-			} else {
-				// Was negative: flip all bits
-				bits = ^sortableBits
-			}
-			val := math.Float64frombits(bits)
-			tuple = append(tuple, val)
-		case markerString:
-			// Sortable string decoding: read until 0x00 0x00 terminator, unescape 0x00 0xFF -> 0x00
-			var data []byte
-		decodeString:
-			for {
-				b, err := buf.ReadByte()
-				if err != nil {
-					return nil, fmt.Errorf("unexpected end while reading string")
-				}
-				if b == stringEscapeByte {
-					next, err := buf.ReadByte()
-					if err != nil {
-						return nil, fmt.Errorf("unexpected end after escape byte")
-					}
-					switch next {
-					case stringEscapeNext:
-						// Escaped 0x00
-						data = append(data, stringEscapeByte)
-					case stringTermNext:
-						// Found terminator 0x00 0x00
-						break decodeString
-					default:
-						return nil, fmt.Errorf("invalid escape sequence: 0x00 0x%02x", next)
-					}
-				} else {
-					data = append(data, b)
-				}
-			}
-			tuple = append(tuple, string(data))
-		case markerBytes:
-			var length uint32
-			err := binary.Read(buf, binary.BigEndian, &length)
-			if err != nil {
-				return nil, err
-			}
-			data := make([]byte, length)
-			_, err = buf.Read(data)
-			if err != nil {
-				return nil, err
-			}
-			tuple = append(tuple, data)
-		default:
-			return nil, fmt.Errorf("unknown marker: %02x", marker)
+// nullMarker returns the marker of the specified null element before complemented for descending order.
+func nullMarker(ov document.OrderedValue) byte {
+	if ov.IsNullsLast() != ov.IsDescending() {
+		return markerNullLast
+	}
+	return markerNull
+}
+
+// isNestedNull returns true if the specified element is encoded as the nested null byte.
+func isNestedNull(elem any) bool {
+	if elem == nil {
+		return true
+	}
+	ov, ok := elem.(document.OrderedValue)
+	return ok && ov.Value == nil && !ov.IsDescending() && nullMarker(ov) == markerNull
+}
+
+func invertBytes(b []byte) {
+	for n := range b {
+		b[n] = ^b[n]
+	}
+}
+
+// Unpack decodes a byte slice into a tuple.
+func Unpack(data []byte) (Tuple, error) {
+	tuple := Tuple{}
+	dec := NewDecoder(data)
+	for dec.More() {
+		elem, err := dec.Decode()
+		if err != nil {
+			return nil, err
 		}
+		tuple = append(tuple, elem)
 	}
+	return tuple, nil
+}
 
-	return tuple, err
+func unpackOrderedElement(r *reader, marker byte) (any, error) {
+	switch marker {
+	case markerNullLast:
+		return document.Asc(nil).WithNulls(document.NullsLast), nil
+	case ^markerNullLast:
+		return document.Desc(nil).WithNulls(document.NullsFirst), nil
+	}
+	if marker&markerDescendingMask == 0 {
+		return unpackElement(r, marker)
+	}
+	// Descending element: decode the complemented bytes as an ascending element
+	r.invert()
+	elem, err := unpackElement(r, ^marker)
+	r.invert()
+	if err != nil {
+		return nil, err
+	}
+	return document.Desc(elem), nil
 }
 
-func newTupleWith(key document.Key) (Tuple, error) {
-	tpl := make(Tuple, len(key))
-	copy(tpl, key)
-	return tpl, nil
+func unpackNested(r *reader) (document.Key, error) {
+	key := document.NewKey()
+	for {
+		marker, err := r.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("unexpected end while reading nested tuple")
+		}
+		if marker == tupleNullByte {
+			next, err := r.ReadByte()
+			if err != nil {
+				return nil, fmt.Errorf("unexpected end after nested null byte")
+			}
+			switch next {
+			case tupleNullNext:
+				key = append(key, nil)
+				continue
+			case tupleTermNext:
+				return key, nil
+			default:
+				return nil, fmt.Errorf("invalid nested tuple sequence: 0x00 0x%02x", next)
+			}
+		}
+		elem, err := unpackOrderedElement(r, marker)
+		if err != nil {
+			return nil, err
+		}
+		key = append(key, elem)
+	}
 }
 
-func newKeyWith(tpl Tuple) document.Key {
-	key := make(document.Key, len(tpl))
-	copy(key, tpl)
-	return key
+// nolint: gocyclo, maintidx
+func unpackElement(r *reader, marker byte) (any, error) {
+	if markerCompactIntMin <= marker && marker <= markerCompactIntMax {
+		return unpackCompactInt(r, marker)
+	}
+	switch marker {
+	case markerNull:
+		return nil, nil
+	case markerTrue:
+		return true, nil
+	case markerFalse:
+		return false, nil
+	case markerInt:
+		sortable, err := r.ReadUint64()
+		if err != nil {
+			return nil, err
+		}
+		// Reverse sortable int encoding: flip sign bit back
+		return int64(sortable ^ (1 << 63)), nil
+	case markerUint:
+		return r.ReadUint64()
+	case markerFloat32:
+		return unpackFloat32(r)
+	case markerFloat:
+		return unpackFloat64(r)
+	case markerString:
+		return r.ReadEscapedString()
+	case markerSortableBytes:
+		return r.ReadEscaped()
+	case markerBytes:
+		length, err := r.ReadUint32()
+		if err != nil {
+			return nil, err
+		}
+		data := make([]byte, length)
+		if err := r.ReadFull(data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	case markerTuple:
+		return unpackNested(r)
+	case markerNumber:
+		n, err := unpackNumber(r)
+		if err != nil {
+			return nil, err
+		}
+		return n.Value()
+	case markerBigInt:
+		n, err := unpackNumber(r)
+		if err != nil {
+			return nil, err
+		}
+		return n.BigInt()
+	case markerBigFloat:
+		n, err := unpackNumber(r)
+		if err != nil {
+			return nil, err
+		}
+		return n.BigFloat()
+	case markerDecimal:
+		n, err := unpackNumber(r)
+		if err != nil {
+			return nil, err
+		}
+		return n.Decimal()
+	case markerUUID:
+		var uuid [16]byte
+		if err := r.ReadFull(uuid[:]); err != nil {
+			return nil, err
+		}
+		return uuid, nil
+	case markerFixedBytes:
+		width, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if width == 0 {
+			return nil, fmt.Errorf("invalid fixed bytes width: %d", width)
+		}
+		data := make([]byte, width)
+		if err := r.ReadFull(data); err != nil {
+			return nil, err
+		}
+		// Decode as a byte array of the same width
+		fixed := reflect.New(reflect.ArrayOf(int(width), reflect.TypeFor[byte]())).Elem()
+		reflect.Copy(fixed, reflect.ValueOf(data))
+		return fixed.Interface(), nil
+	case markerTime:
+		sortable, err := r.ReadUint64()
+		if err != nil {
+			return nil, err
+		}
+		nsec, err := r.ReadUint32()
+		if err != nil {
+			return nil, err
+		}
+		return time.Unix(int64(sortable^(1<<63)), int64(nsec)).UTC(), nil
+	case markerDuration:
+		sortable, err := r.ReadUint64()
+		if err != nil {
+			return nil, err
+		}
+		return time.Duration(int64(sortable ^ (1 << 63))), nil
+	default:
+		return nil, fmt.Errorf("unknown marker: %02x", marker)
+	}
 }
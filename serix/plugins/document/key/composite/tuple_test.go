@@ -0,0 +1,232 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composite
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+func TestLegacyBytesDecoding(t *testing.T) {
+	// Length-prefixed bytes written by the previous encoding
+	legacy := []byte{markerBytes, 0x00, 0x00, 0x00, 0x03, 'a', 0x00, 'b', markerString, 'c', 0x00, 0x00}
+
+	tpl, err := Unpack(legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tpl) != 2 {
+		t.Fatalf("%v: unexpected length %d", tpl, len(tpl))
+	}
+	b, ok := tpl[0].([]byte)
+	if !ok || !bytes.Equal(b, []byte{'a', 0x00, 'b'}) {
+		t.Errorf("%v != % x", tpl[0], []byte{'a', 0x00, 'b'})
+	}
+	if s, ok := tpl[1].(string); !ok || s != "c" {
+		t.Errorf("%v != %s", tpl[1], "c")
+	}
+
+	// Re-encoding uses the sortable bytes encoding
+	packed, err := tpl.Pack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if packed[0] != markerSortableBytes {
+		t.Errorf("%02x != %02x", packed[0], markerSortableBytes)
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	key := document.NewKeyWith("users", document.Desc(int64(42)), document.NewKeyWith(nil, document.Desc("a")), []byte{0x00})
+	encoded, err := NewCoder().EncodeKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dec := NewDecoder(encoded)
+	for n, elem := range key {
+		if !dec.More() {
+			t.Fatalf("%d: no more elements", n)
+		}
+		v, err := dec.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !document.NewKeyWith(elem).Equal(document.NewKeyWith(v)) {
+			t.Errorf("%d: %v != %v", n, v, elem)
+		}
+	}
+	if dec.More() {
+		t.Errorf("unexpected more elements")
+	}
+	if _, err := dec.Decode(); err == nil {
+		t.Errorf("decoding after the last element should fail")
+	}
+}
+
+func BenchmarkDecoder(b *testing.B) {
+	key := document.NewKeyWith("users", int64(42), "alice@example.com", []byte{0x00, 0x01, 0xFF}, 3.14, true)
+	encoded, err := NewCoder().EncodeKey(key)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("Unpack", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := Unpack(encoded); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			dec := NewDecoder(encoded)
+			for dec.More() {
+				if _, err := dec.Decode(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
+
+func TestPartialDecoding(t *testing.T) {
+	key := document.NewKeyWith(
+		"users",
+		nil,
+		true,
+		int64(-42),
+		uint64(42),
+		1.5,
+		[]byte{0x00, 0xFF},
+		document.Desc("a\x00b"),
+		document.NewKeyWith(nil, document.Desc(int64(1)), "x"),
+		big.NewInt(-12345),
+		[16]byte{0x01},
+		[8]byte{0x02},
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Hour,
+		document.Desc(big.NewFloat(-1.5)),
+		int64(7),
+	)
+
+	compact := NewConfig()
+	compact.SetCompactIntegerEnabled(true)
+	unified := NewConfig()
+	unified.SetNumericUnifiedEnabled(true)
+
+	for _, config := range []*Config{NewConfig(), compact, unified} {
+		encoded, err := Tuple(key).PackWith(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		offsets, err := Offsets(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(offsets) != len(key) {
+			t.Fatalf("%d offsets != %d elements", len(offsets), len(key))
+		}
+		for n := range key {
+			end := len(encoded)
+			if n+1 < len(offsets) {
+				end = offsets[n+1]
+			}
+			elem, err := Unpack(encoded[offsets[n]:end])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !document.NewKeyWith(key[n]).Equal(document.Key(elem)) {
+				t.Errorf("%d: %v != %v", n, elem, key[n])
+			}
+
+			rest, err := SkipN(encoded, n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(rest, encoded[offsets[n]:]) {
+				t.Errorf("%d: % x != % x", n, rest, encoded[offsets[n]:])
+			}
+
+			prefix, err := UnpackN(encoded, n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !key[:n].Equal(document.Key(prefix)) {
+				t.Errorf("%d: %v != %v", n, prefix, key[:n])
+			}
+		}
+
+		// The last element is the primary key suffix
+		coder := NewCoder()
+		coder.Config = config
+		rest, err := coder.SkipKeyElements(encoded, len(key)-1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pk, err := coder.DecodeKey(rest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !document.NewKeyWith(int64(7)).Equal(pk) {
+			t.Errorf("%v != %v", pk, int64(7))
+		}
+		if _, err := coder.SkipKeyElements(encoded, len(key)+1); err == nil {
+			t.Errorf("skipping more elements than the key should fail")
+		}
+	}
+}
+
+func TestCorruptNumberDecoding(t *testing.T) {
+	corruptNumber := func(marker byte, exp int64) []byte {
+		b := []byte{marker, numberPositive}
+		b = binary.BigEndian.AppendUint64(b, uint64(exp)^(1<<63))
+		return append(b, '1', numberDigitsTerminator)
+	}
+	coder := NewCoder()
+	for _, marker := range []byte{markerBigInt, markerBigFloat, markerDecimal, markerNumber} {
+		for _, exp := range []int64{1 << 40, -(1 << 40), math.MaxInt64, math.MinInt64, document.DecimalMaxScale + 2, -document.DecimalMaxScale} {
+			if key, err := coder.DecodeKey(corruptNumber(marker, exp)); err == nil {
+				t.Errorf("%02x %d: %v", marker, exp, key)
+			}
+		}
+		// The largest and smallest exponents are decodable
+		for _, exp := range []int64{document.DecimalMaxScale + 1, -document.DecimalMaxScale + 1} {
+			if marker == markerBigInt && exp < 0 {
+				continue
+			}
+			if _, err := coder.DecodeKey(corruptNumber(marker, exp)); err != nil {
+				t.Errorf("%02x %d: %v", marker, exp, err)
+			}
+		}
+	}
+
+	// Numbers out of the range are not encodable
+	huge := new(big.Int).Exp(big.NewInt(10), big.NewInt(document.DecimalMaxScale+1), nil)
+	for _, v := range []any{huge, document.NewDecimal(big.NewInt(1), math.MaxInt32), document.NewDecimal(big.NewInt(1), math.MinInt32)} {
+		if _, err := coder.EncodeKey(document.NewKeyWith(v)); err == nil {
+			t.Errorf("%v: expected error", v)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fdbtuple
+
+import (
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+// Coder represents a key coder compatible with the FoundationDB tuple layer.
+type Coder struct {
+}
+
+// NewCoder returns a new FoundationDB tuple key coder instance.
+func NewCoder() *Coder {
+	return &Coder{}
+}
+
+// Name returns the name of the coder.
+func (s *Coder) Name() string {
+	return "fdbtuple"
+}
+
+// EncodeKey returns the encoded bytes from the specified key if available, otherwise returns an error.
+func (s *Coder) EncodeKey(key document.Key) ([]byte, error) {
+	return Tuple(key).Pack()
+}
+
+// AppendKey appends the encoded bytes of the specified key to the specified byte slice and returns the extended byte slice.
+func (s *Coder) AppendKey(dst []byte, key document.Key) ([]byte, error) {
+	return Tuple(key).Append(dst)
+}
+
+// EncodeVersionstampedKey returns the encoded bytes from the specified key which has exactly one incomplete
+// versionstamp, followed by the 4-byte little-endian offset of the versionstamp for the SetVersionstampedKey
+// atomic operation of FoundationDB.
+func (s *Coder) EncodeVersionstampedKey(key document.Key) ([]byte, error) {
+	return Tuple(key).PackWithVersionstamp()
+}
+
+// DecodeKey returns the decoded key from the specified bytes if available, otherwise returns an error.
+func (s *Coder) DecodeKey(b []byte) (document.Key, error) {
+	tpl, err := Unpack(b)
+	if err != nil {
+		return nil, err
+	}
+	return document.Key(tpl), nil
+}
+
+// PrefixRange returns the range [begin, end) of the encoded keys beginning with all elements of the specified key prefix.
+// Strings and bytes are terminated by a single 0x00 which may be followed by the escape byte 0xFF, so that the end is
+// the prefix followed by 0xFF as the range of the FoundationDB tuple layer, instead of the incremented prefix.
+func (s *Coder) PrefixRange(prefix document.Key) ([]byte, []byte, error) {
+	begin, err := s.EncodeKey(prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	end := make([]byte, len(begin), len(begin)+1)
+	copy(end, begin)
+	return begin, append(end, 0xFF), nil
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fdbtuple
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-serix/serix/document"
+	"github.com/cybergarage/go-serix/serixtest"
+)
+
+func TestFDBTupleCoder(t *testing.T) {
+	serixtest.FDBTupleKeyCoderSuite(t, NewCoder())
+}
+
+func TestVersionstamp(t *testing.T) {
+	coder := NewCoder()
+
+	tr := [10]byte{0x00, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x00, 0x01}
+	key := document.NewKeyWith("log", NewVersionstamp(tr, 7))
+	expected := []byte{0x02, 'l', 'o', 'g', 0x00, 0x33, 0x00, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x00, 0x01, 0x00, 0x07}
+
+	encoded, err := coder.EncodeKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded, expected) {
+		t.Errorf("% x != % x", encoded, expected)
+	}
+	decKey, err := coder.DecodeKey(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !key.Equal(decKey) {
+		t.Errorf("%v != %v", decKey, key)
+	}
+
+	// Incomplete versionstamps are only allowed with the offset for SetVersionstampedKey
+	key = document.NewKeyWith("log", NewIncompleteVersionstamp(7))
+	if _, err := coder.EncodeKey(key); !errors.Is(err, document.ErrInvalid) {
+		t.Errorf("incomplete versionstamp should be rejected: %v", err)
+	}
+	encoded, err = coder.EncodeVersionstampedKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = []byte{0x02, 'l', 'o', 'g', 0x00, 0x33, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x00, 0x07, 0x06, 0x00, 0x00, 0x00}
+	if !bytes.Equal(encoded, expected) {
+		t.Errorf("% x != % x", encoded, expected)
+	}
+	if _, err := coder.EncodeVersionstampedKey(document.NewKeyWith("log")); !errors.Is(err, document.ErrInvalid) {
+		t.Errorf("key without versionstamp should be rejected: %v", err)
+	}
+}
+
+func TestUnsupportedElements(t *testing.T) {
+	coder := NewCoder()
+	for _, v := range []any{document.Desc(int64(1)), [8]byte{}} {
+		if _, err := coder.EncodeKey(document.NewKeyWith(v)); !errors.Is(err, document.ErrNotSupported) {
+			t.Errorf("%v should not be supported: %v", v, err)
+		}
+	}
+}
+
+func BenchmarkFDBTupleCoder(b *testing.B) {
+	serixtest.KeyCoderBenchmark(b, NewCoder())
+}
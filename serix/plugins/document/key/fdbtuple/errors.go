@@ -0,0 +1,37 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fdbtuple
+
+import (
+	"fmt"
+
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+func newErrElementNotSupported(v any) error {
+	return fmt.Errorf("element (%T:%v) is %w", v, v, document.ErrNotSupported)
+}
+
+func newErrTypeCodeInvalid(code byte) error {
+	return fmt.Errorf("type code (%02x) is %w", code, document.ErrInvalid)
+}
+
+func newErrTupleTruncated(code byte) error {
+	return fmt.Errorf("element (%02x) is truncated: %w", code, document.ErrInvalid)
+}
+
+func newErrVersionstampCount(n int) error {
+	return fmt.Errorf("%d incomplete versionstamps are %w, exactly one is required", n, document.ErrInvalid)
+}
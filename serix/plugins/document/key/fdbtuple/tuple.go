@@ -0,0 +1,456 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fdbtuple
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+// Tuple represents a FoundationDB tuple.
+// The elements are encoded with the type codes of the FoundationDB tuple layer specification
+// (https://github.com/apple/foundationdb/blob/main/design/tuple.md), so that the encoded keys can be
+// read by FoundationDB tools and the bindings of other languages, and sort in the tuple order:
+//
+//	nil                      - null (0x00)
+//	[]byte                   - byte string (0x01) with 0x00 escaped as 0x00 0xFF and terminated by 0x00
+//	string                   - UTF-8 string (0x02) with the same escaping as byte strings
+//	document.Key, []any      - nested tuple (0x05) terminated by 0x00, nested nulls are encoded as 0x00 0xFF
+//	int, uint and *big.Int   - integers (0x0B to 0x1D) with the byte length in the type code
+//	float32, float64         - IEEE 754 floats (0x20, 0x21) with the sign bit or all bits flipped
+//	bool                     - false (0x26) and true (0x27)
+//	[16]byte                 - UUID (0x30)
+//	Versionstamp             - 96-bit versionstamp (0x33)
+//
+// Integers decode back as int64, as uint64 if they exceed math.MaxInt64, or as *big.Int if they exceed
+// 64 bits. Descending elements and the other types are not supported by the specification.
+type Tuple []any
+
+const (
+	codeNull         byte = 0x00
+	codeBytes        byte = 0x01
+	codeString       byte = 0x02
+	codeNested       byte = 0x05
+	codeNegIntLong   byte = 0x0B
+	codeIntZero      byte = 0x14
+	codePosIntLong   byte = 0x1D
+	codeFloat        byte = 0x20
+	codeDouble       byte = 0x21
+	codeFalse        byte = 0x26
+	codeTrue         byte = 0x27
+	codeUUID         byte = 0x30
+	codeVersionstamp byte = 0x33
+)
+
+const (
+	escapeByte      byte = 0x00
+	escapeNext      byte = 0xFF
+	terminatorByte  byte = 0x00
+	uuidLength           = 16
+	intMaxLength         = 8
+	intLongMaxBytes      = 255
+)
+
+type packer struct {
+	buf []byte
+	// versionstamps has the offsets of the incomplete versionstamps.
+	versionstamps []int
+}
+
+// Pack encodes the tuple into a byte slice using the FoundationDB tuple encoding.
+// Incomplete versionstamps are not allowed, use PackWithVersionstamp instead.
+func (t Tuple) Pack() ([]byte, error) {
+	return t.Append(nil)
+}
+
+// Append appends the encoded tuple to the specified byte slice using the FoundationDB tuple encoding,
+// and returns the extended byte slice. Incomplete versionstamps are not allowed.
+func (t Tuple) Append(dst []byte) ([]byte, error) {
+	p := packer{buf: dst}
+	if err := p.packElements(t, false); err != nil {
+		return nil, err
+	}
+	if len(p.versionstamps) != 0 {
+		return nil, newErrVersionstampCount(len(p.versionstamps))
+	}
+	return p.buf, nil
+}
+
+// PackWithVersionstamp encodes the tuple which has exactly one incomplete versionstamp, followed by
+// the 4-byte little-endian offset of the versionstamp as required by the SetVersionstampedKey operation.
+func (t Tuple) PackWithVersionstamp() ([]byte, error) {
+	p := packer{}
+	if err := p.packElements(t, false); err != nil {
+		return nil, err
+	}
+	if len(p.versionstamps) != 1 {
+		return nil, newErrVersionstampCount(len(p.versionstamps))
+	}
+	if math.MaxUint32 < p.versionstamps[0] {
+		return nil, fmt.Errorf("versionstamp offset (%d) is %w", p.versionstamps[0], document.ErrInvalid)
+	}
+	return binary.LittleEndian.AppendUint32(p.buf, uint32(p.versionstamps[0])), nil
+}
+
+func (p *packer) packElements(elems []any, nested bool) error {
+	for _, elem := range elems {
+		if err := p.packElement(elem, nested); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nolint: gocyclo
+func (p *packer) packElement(elem any, nested bool) error {
+	switch v := elem.(type) {
+	case document.OrderedValue:
+		// Descending elements and nulls last elements have no representation in the tuple layer
+		if v.IsDescending() || (v.Value == nil && v.IsNullsLast()) {
+			return newErrElementNotSupported(v)
+		}
+		return p.packElement(v.Value, nested)
+	case nil:
+		p.buf = append(p.buf, codeNull)
+		if nested {
+			p.buf = append(p.buf, escapeNext)
+		}
+	case []byte:
+		p.buf = append(p.buf, codeBytes)
+		packEscaped(p, v)
+	case string:
+		p.buf = append(p.buf, codeString)
+		packEscaped(p, v)
+	case document.Key:
+		return p.packNested(v)
+	case []any:
+		return p.packNested(v)
+	case Tuple:
+		return p.packNested(v)
+	case int, int8, int16, int32, int64:
+		iv, _ := document.Int64From(v)
+		if iv < 0 {
+			// The magnitude of math.MinInt64 fits in uint64
+			p.packUint(uint64(-(iv+1))+1, true)
+		} else {
+			p.packUint(uint64(iv), false)
+		}
+	case uint, uint8, uint16, uint32, uint64:
+		uv, _ := document.Uint64From(v)
+		p.packUint(uv, false)
+	case *big.Int:
+		if v == nil {
+			return newErrElementNotSupported(v)
+		}
+		return p.packBigInt(v)
+	case float32:
+		p.buf = append(p.buf, codeFloat)
+		p.buf = binary.BigEndian.AppendUint32(p.buf, sortableFloat32Bits(v))
+	case float64:
+		p.buf = append(p.buf, codeDouble)
+		p.buf = binary.BigEndian.AppendUint64(p.buf, sortableFloat64Bits(v))
+	case bool:
+		if v {
+			p.buf = append(p.buf, codeTrue)
+		} else {
+			p.buf = append(p.buf, codeFalse)
+		}
+	case [uuidLength]byte:
+		p.buf = append(p.buf, codeUUID)
+		p.buf = append(p.buf, v[:]...)
+	case Versionstamp:
+		p.buf = append(p.buf, codeVersionstamp)
+		if !v.IsComplete() {
+			p.versionstamps = append(p.versionstamps, len(p.buf))
+		}
+		p.buf = append(p.buf, v.Bytes()...)
+	default:
+		return newErrElementNotSupported(v)
+	}
+	return nil
+}
+
+func packEscaped[T string | []byte](p *packer, b T) {
+	for n := range len(b) {
+		c := b[n]
+		p.buf = append(p.buf, c)
+		if c == escapeByte {
+			p.buf = append(p.buf, escapeNext)
+		}
+	}
+	p.buf = append(p.buf, terminatorByte)
+}
+
+func (p *packer) packNested(elems []any) error {
+	p.buf = append(p.buf, codeNested)
+	if err := p.packElements(elems, true); err != nil {
+		return err
+	}
+	p.buf = append(p.buf, terminatorByte)
+	return nil
+}
+
+// packUint writes the integer of the specified magnitude with the byte length in the type code.
+// Negative integers are written as the ones' complement of the magnitude.
+func (p *packer) packUint(mag uint64, neg bool) {
+	n := (bits.Len64(mag) + 7) / 8
+	if neg {
+		p.buf = append(p.buf, codeIntZero-byte(n))
+		mag = ^mag
+	} else {
+		p.buf = append(p.buf, codeIntZero+byte(n))
+	}
+	for i := n - 1; 0 <= i; i-- {
+		p.buf = append(p.buf, byte(mag>>(8*i)))
+	}
+}
+
+// packBigInt writes the integer which may exceed 64 bits with a length byte after the type code.
+func (p *packer) packBigInt(v *big.Int) error {
+	mag := new(big.Int).Abs(v)
+	if mag.IsUint64() {
+		p.packUint(mag.Uint64(), v.Sign() < 0)
+		return nil
+	}
+	b := mag.Bytes()
+	if intLongMaxBytes < len(b) {
+		return newErrElementNotSupported(v)
+	}
+	if 0 < v.Sign() {
+		p.buf = append(p.buf, codePosIntLong, byte(len(b)))
+		p.buf = append(p.buf, b...)
+		return nil
+	}
+	p.buf = append(p.buf, codeNegIntLong, byte(len(b))^0xFF)
+	for _, c := range b {
+		p.buf = append(p.buf, ^c)
+	}
+	return nil
+}
+
+// NaN values are canonicalized to the positive quiet NaN of the other tuple layer bindings,
+// which sorts after positive infinity.
+const (
+	canonicalFloat32NaN uint32 = 0x7FC00000
+	canonicalFloat64NaN uint64 = 0x7FF8000000000000
+)
+
+func sortableFloat32Bits(v float32) uint32 {
+	b := math.Float32bits(v)
+	if math.IsNaN(float64(v)) {
+		b = canonicalFloat32NaN
+	}
+	if b&(1<<31) != 0 {
+		return ^b
+	}
+	return b ^ (1 << 31)
+}
+
+func sortableFloat64Bits(v float64) uint64 {
+	b := math.Float64bits(v)
+	if math.IsNaN(v) {
+		b = canonicalFloat64NaN
+	}
+	if b&(1<<63) != 0 {
+		return ^b
+	}
+	return b ^ (1 << 63)
+}
+
+type unpacker struct {
+	buf []byte
+	pos int
+}
+
+// Unpack decodes the specified bytes encoded with the FoundationDB tuple encoding.
+func Unpack(b []byte) (Tuple, error) {
+	u := &unpacker{buf: b}
+	tpl := Tuple{}
+	for u.pos < len(u.buf) {
+		elem, err := u.unpackElement()
+		if err != nil {
+			return nil, err
+		}
+		tpl = append(tpl, elem)
+	}
+	return tpl, nil
+}
+
+func (u *unpacker) next(code byte, n int) ([]byte, error) {
+	if len(u.buf)-u.pos < n {
+		return nil, newErrTupleTruncated(code)
+	}
+	b := u.buf[u.pos : u.pos+n]
+	u.pos += n
+	return b, nil
+}
+
+// nolint: gocyclo
+func (u *unpacker) unpackElement() (any, error) {
+	code := u.buf[u.pos]
+	u.pos++
+	switch {
+	case code == codeNull:
+		return nil, nil
+	case code == codeBytes:
+		return u.unpackEscaped(code)
+	case code == codeString:
+		b, err := u.unpackEscaped(code)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case code == codeNested:
+		return u.unpackNested()
+	case code == codeNegIntLong || code == codePosIntLong:
+		return u.unpackBigInt(code)
+	case codeIntZero-intMaxLength <= code && code <= codeIntZero+intMaxLength:
+		return u.unpackInt(code)
+	case code == codeFloat:
+		b, err := u.next(code, 4)
+		if err != nil {
+			return nil, err
+		}
+		v := binary.BigEndian.Uint32(b)
+		if v&(1<<31) != 0 {
+			return math.Float32frombits(v ^ (1 << 31)), nil
+		}
+		return math.Float32frombits(^v), nil
+	case code == codeDouble:
+		b, err := u.next(code, 8)
+		if err != nil {
+			return nil, err
+		}
+		v := binary.BigEndian.Uint64(b)
+		if v&(1<<63) != 0 {
+			return math.Float64frombits(v ^ (1 << 63)), nil
+		}
+		return math.Float64frombits(^v), nil
+	case code == codeFalse:
+		return false, nil
+	case code == codeTrue:
+		return true, nil
+	case code == codeUUID:
+		b, err := u.next(code, uuidLength)
+		if err != nil {
+			return nil, err
+		}
+		return [uuidLength]byte(b), nil
+	case code == codeVersionstamp:
+		b, err := u.next(code, versionstampLength)
+		if err != nil {
+			return nil, err
+		}
+		return newVersionstampFromBytes(b), nil
+	default:
+		return nil, newErrTypeCodeInvalid(code)
+	}
+}
+
+func (u *unpacker) unpackEscaped(code byte) ([]byte, error) {
+	b := []byte{}
+	for u.pos < len(u.buf) {
+		c := u.buf[u.pos]
+		u.pos++
+		if c != escapeByte {
+			b = append(b, c)
+			continue
+		}
+		if u.pos < len(u.buf) && u.buf[u.pos] == escapeNext {
+			b = append(b, escapeByte)
+			u.pos++
+			continue
+		}
+		return b, nil
+	}
+	return nil, newErrTupleTruncated(code)
+}
+
+func (u *unpacker) unpackNested() (document.Key, error) {
+	key := document.NewKey()
+	for u.pos < len(u.buf) {
+		if u.buf[u.pos] == codeNull {
+			u.pos++
+			if u.pos < len(u.buf) && u.buf[u.pos] == escapeNext {
+				key = append(key, nil)
+				u.pos++
+				continue
+			}
+			return key, nil
+		}
+		elem, err := u.unpackElement()
+		if err != nil {
+			return nil, err
+		}
+		key = append(key, elem)
+	}
+	return nil, newErrTupleTruncated(codeNested)
+}
+
+func (u *unpacker) unpackInt(code byte) (any, error) {
+	neg := code < codeIntZero
+	n := int(code) - int(codeIntZero)
+	if neg {
+		n = -n
+	}
+	b, err := u.next(code, n)
+	if err != nil {
+		return nil, err
+	}
+	var mag uint64
+	for _, c := range b {
+		mag = mag<<8 | uint64(c)
+	}
+	if !neg {
+		if mag <= math.MaxInt64 {
+			return int64(mag), nil
+		}
+		return mag, nil
+	}
+	// Reverse the ones' complement of the magnitude
+	mag = ^mag & (math.MaxUint64 >> (64 - 8*n))
+	if mag <= uint64(math.MaxInt64)+1 {
+		return -int64(mag-1) - 1, nil
+	}
+	return new(big.Int).Neg(new(big.Int).SetUint64(mag)), nil
+}
+
+func (u *unpacker) unpackBigInt(code byte) (any, error) {
+	l, err := u.next(code, 1)
+	if err != nil {
+		return nil, err
+	}
+	n := int(l[0])
+	if code == codeNegIntLong {
+		n ^= 0xFF
+	}
+	b, err := u.next(code, n)
+	if err != nil {
+		return nil, err
+	}
+	if code == codePosIntLong {
+		return new(big.Int).SetBytes(b), nil
+	}
+	mag := make([]byte, n)
+	for i, c := range b {
+		mag[i] = ^c
+	}
+	return new(big.Int).Neg(new(big.Int).SetBytes(mag)), nil
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fdbtuple
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	versionstampTransactionLength = 10
+	versionstampLength            = 12
+)
+
+// Versionstamp represents a 96-bit FoundationDB versionstamp, a 10-byte transaction version assigned
+// by the database at commit time followed by a 2-byte user version ordering keys within a transaction.
+type Versionstamp struct {
+	// TransactionVersion is the commit version and batch order of the transaction.
+	TransactionVersion [versionstampTransactionLength]byte
+	// UserVersion is the order of the key within the transaction.
+	UserVersion uint16
+}
+
+// NewVersionstamp returns a new complete versionstamp with the specified transaction and user versions.
+func NewVersionstamp(tr [versionstampTransactionLength]byte, user uint16) Versionstamp {
+	return Versionstamp{
+		TransactionVersion: tr,
+		UserVersion:        user,
+	}
+}
+
+// NewIncompleteVersionstamp returns a new incomplete versionstamp whose transaction version is filled
+// by the database when the key is written with Coder.EncodeVersionstampedKey.
+func NewIncompleteVersionstamp(user uint16) Versionstamp {
+	var tr [versionstampTransactionLength]byte
+	for n := range tr {
+		tr[n] = 0xFF
+	}
+	return NewVersionstamp(tr, user)
+}
+
+// IsComplete returns true if the transaction version of the versionstamp is assigned.
+func (vs Versionstamp) IsComplete() bool {
+	for _, b := range vs.TransactionVersion {
+		if b != 0xFF {
+			return true
+		}
+	}
+	return false
+}
+
+// Bytes returns the 12-byte representation of the versionstamp.
+func (vs Versionstamp) Bytes() []byte {
+	b := make([]byte, versionstampLength)
+	copy(b, vs.TransactionVersion[:])
+	binary.BigEndian.PutUint16(b[versionstampTransactionLength:], vs.UserVersion)
+	return b
+}
+
+// String returns the string representation of the versionstamp.
+func (vs Versionstamp) String() string {
+	return fmt.Sprintf("Versionstamp(%s, %d)", hex.EncodeToString(vs.TransactionVersion[:]), vs.UserVersion)
+}
+
+func newVersionstampFromBytes(b []byte) Versionstamp {
+	var vs Versionstamp
+	copy(vs.TransactionVersion[:], b[:versionstampTransactionLength])
+	vs.UserVersion = binary.BigEndian.Uint16(b[versionstampTransactionLength:])
+	return vs
+}
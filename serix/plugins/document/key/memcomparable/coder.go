@@ -0,0 +1,54 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memcomparable
+
+import (
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+// Coder represents a memcomparable key coder compatible with the MySQL and TiDB style codecs.
+type Coder struct {
+}
+
+// NewCoder returns a new memcomparable key coder instance.
+func NewCoder() *Coder {
+	return &Coder{}
+}
+
+// Name returns the name of the coder.
+func (s *Coder) Name() string {
+	return "memcomparable"
+}
+
+// EncodeKey returns the encoded bytes from the specified key if available, otherwise returns an error.
+func (s *Coder) EncodeKey(key document.Key) ([]byte, error) {
+	return encodeDatums(nil, key)
+}
+
+// AppendKey appends the encoded bytes of the specified key to the specified byte slice and returns the extended byte slice.
+func (s *Coder) AppendKey(dst []byte, key document.Key) ([]byte, error) {
+	return encodeDatums(dst, key)
+}
+
+// DecodeKey returns the decoded key from the specified bytes if available, otherwise returns an error.
+func (s *Coder) DecodeKey(b []byte) (document.Key, error) {
+	return decodeDatums(b)
+}
+
+// PrefixRange returns the range [begin, end) of the encoded keys beginning with all elements of the specified key prefix.
+// The end is nil if the range has no upper bound.
+func (s *Coder) PrefixRange(prefix document.Key) ([]byte, []byte, error) {
+	return document.KeyPrefixRange(s, prefix)
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memcomparable
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cybergarage/go-serix/serix/document"
+	"github.com/cybergarage/go-serix/serixtest"
+)
+
+func TestMemcomparableCoder(t *testing.T) {
+	serixtest.KeyCoderSuite(t, NewCoder())
+}
+
+func TestMemcomparableBytes(t *testing.T) {
+	coder := NewCoder()
+
+	vectors := []struct {
+		value    any
+		expected []byte
+	}{
+		{[]byte{}, []byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xF7}},
+		{[]byte{1, 2, 3}, []byte{0x01, 0x01, 0x02, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0xFA}},
+		{
+			[]byte{1, 2, 3, 4, 5, 6, 7, 8},
+			[]byte{0x01, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0xFF, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xF7},
+		},
+		{
+			"123456789",
+			[]byte{0x01, '1', '2', '3', '4', '5', '6', '7', '8', 0xFF, '9', 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xF8},
+		},
+		{nil, []byte{0x00}},
+		{int64(-1), []byte{0x03, 0x7F, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}},
+		{uint64(1), []byte{0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01}},
+		{1.0, []byte{0x05, 0xBF, 0xF0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}},
+		{document.Desc(int64(-1)), []byte{0xFC, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}},
+	}
+
+	for _, v := range vectors {
+		key := document.NewKeyWith(v.value)
+		encoded, err := coder.EncodeKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(encoded, v.expected) {
+			t.Errorf("%v: % x != % x", v.value, encoded, v.expected)
+		}
+		decKey, err := coder.DecodeKey(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !key.Equal(decKey) {
+			t.Errorf("%v != %v", decKey, key)
+		}
+	}
+}
+
+func BenchmarkMemcomparableCoder(b *testing.B) {
+	serixtest.KeyCoderBenchmark(b, NewCoder())
+}
@@ -0,0 +1,266 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memcomparable
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+// Key elements are encoded as datums of the memcomparable format used by the MySQL and TiDB style
+// codecs, a flag byte followed by the comparable payload, so that the encoded keys sort bytewise:
+//
+//	nil              - NilFlag (0x00)
+//	string, []byte   - BytesFlag (0x01) followed by groups of 8 bytes, each padded with 0x00 and
+//	                   followed by a marker byte 0xFF - the number of the padding bytes
+//	int, bool        - IntFlag (0x03) followed by the big-endian int64 with the sign bit flipped
+//	uint             - UintFlag (0x04) followed by the big-endian uint64
+//	float32, float64 - FloatFlag (0x05) followed by the float64 bits with the sign bit or all bits flipped
+//	time.Duration    - DurationFlag (0x07) followed by the nanoseconds as IntFlag
+//
+// Descending elements are encoded as the bitwise complement of the ascending datum, as the descending
+// index columns of TiKV. Like the SQL engines, the datums do not keep the Go types, so strings decode as
+// []byte, booleans as int64 and floats as float64; the other types such as nested keys are not supported.
+
+const (
+	flagNil      byte = 0x00
+	flagBytes    byte = 0x01
+	flagInt      byte = 0x03
+	flagUint     byte = 0x04
+	flagFloat    byte = 0x05
+	flagDuration byte = 0x07
+)
+
+const (
+	groupSize   = 8
+	groupMarker = byte(0xFF)
+	groupPad    = byte(0x00)
+	signMask    = uint64(1 << 63)
+	descMask    = byte(0xFF)
+)
+
+func encodeDatums(buf []byte, elems []any) ([]byte, error) {
+	var err error
+	for _, elem := range elems {
+		buf, err = encodeDatum(buf, elem)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+func encodeDatum(buf []byte, elem any) ([]byte, error) {
+	switch v := elem.(type) {
+	case document.OrderedValue:
+		// Nulls are always the lowest datum, and the null order cannot move them
+		if v.Value == nil && v.IsNullsLast() != v.IsDescending() {
+			return nil, newErrElementNotSupported(v)
+		}
+		n := len(buf)
+		buf, err := encodeDatum(buf, v.Value)
+		if err != nil {
+			return nil, err
+		}
+		if v.IsDescending() {
+			for i := n; i < len(buf); i++ {
+				buf[i] = ^buf[i]
+			}
+		}
+		return buf, nil
+	case nil:
+		return append(buf, flagNil), nil
+	case string:
+		return encodeBytes(append(buf, flagBytes), v), nil
+	case []byte:
+		return encodeBytes(append(buf, flagBytes), v), nil
+	case bool:
+		if v {
+			return encodeInt(append(buf, flagInt), 1), nil
+		}
+		return encodeInt(append(buf, flagInt), 0), nil
+	case time.Duration:
+		return encodeInt(append(buf, flagDuration), int64(v)), nil
+	case int, int8, int16, int32, int64:
+		iv, _ := document.Int64From(v)
+		return encodeInt(append(buf, flagInt), iv), nil
+	case uint, uint8, uint16, uint32, uint64:
+		uv, _ := document.Uint64From(v)
+		return binary.BigEndian.AppendUint64(append(buf, flagUint), uv), nil
+	case float32:
+		return encodeFloat(append(buf, flagFloat), float64(v)), nil
+	case float64:
+		return encodeFloat(append(buf, flagFloat), v), nil
+	default:
+		return nil, newErrElementNotSupported(v)
+	}
+}
+
+// encodeBytes appends the bytes in groups of 8 bytes, each followed by a marker of the padding length.
+// A data whose length is a multiple of 8 is followed by an empty group.
+func encodeBytes[T string | []byte](buf []byte, data T) []byte {
+	for n := 0; n <= len(data); n += groupSize {
+		remain := len(data) - n
+		padCount := 0
+		if groupSize <= remain {
+			buf = append(buf, data[n:n+groupSize]...)
+		} else {
+			padCount = groupSize - remain
+			buf = append(buf, data[n:]...)
+			for range padCount {
+				buf = append(buf, groupPad)
+			}
+		}
+		buf = append(buf, groupMarker-byte(padCount))
+	}
+	return buf
+}
+
+func encodeInt(buf []byte, v int64) []byte {
+	return binary.BigEndian.AppendUint64(buf, uint64(v)^signMask)
+}
+
+// encodeFloat appends the float bits which sort as the float value, -0.0 is encoded as 0.0
+// and all NaN values are encoded as the quiet NaN returned by math.NaN after positive infinity.
+func encodeFloat(buf []byte, v float64) []byte {
+	u := math.Float64bits(v)
+	if math.IsNaN(v) {
+		u = math.Float64bits(math.NaN()) | signMask
+	} else if 0 <= v {
+		u |= signMask
+	} else {
+		u = ^u
+	}
+	return binary.BigEndian.AppendUint64(buf, u)
+}
+
+type decoder struct {
+	buf  []byte
+	pos  int
+	mask byte
+}
+
+func decodeDatums(b []byte) (document.Key, error) {
+	d := &decoder{buf: b}
+	key := document.NewKey()
+	for d.pos < len(d.buf) {
+		elem, err := d.decodeDatum()
+		if err != nil {
+			return nil, err
+		}
+		key = append(key, elem)
+	}
+	return key, nil
+}
+
+// next returns the next n bytes, complemented if the datum is descending.
+func (d *decoder) next(flag byte, n int) ([]byte, error) {
+	if len(d.buf)-d.pos < n {
+		return nil, newErrDatumTruncated(flag)
+	}
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = d.buf[d.pos+i] ^ d.mask
+	}
+	d.pos += n
+	return b, nil
+}
+
+func (d *decoder) decodeDatum() (any, error) {
+	flag := d.buf[d.pos]
+	d.pos++
+	d.mask = 0
+	// Flags of ascending datums are small, so that complemented flags are descending datums
+	desc := flag&0x80 != 0
+	if desc {
+		d.mask = descMask
+		flag = ^flag
+	}
+	v, err := d.decodeValue(flag)
+	if err != nil {
+		return nil, err
+	}
+	if desc {
+		return document.Desc(v), nil
+	}
+	return v, nil
+}
+
+func (d *decoder) decodeValue(flag byte) (any, error) {
+	switch flag {
+	case flagNil:
+		return nil, nil
+	case flagBytes:
+		return d.decodeBytes(flag)
+	case flagInt, flagDuration:
+		b, err := d.next(flag, 8)
+		if err != nil {
+			return nil, err
+		}
+		v := int64(binary.BigEndian.Uint64(b) ^ signMask)
+		if flag == flagDuration {
+			return time.Duration(v), nil
+		}
+		return v, nil
+	case flagUint:
+		b, err := d.next(flag, 8)
+		if err != nil {
+			return nil, err
+		}
+		return binary.BigEndian.Uint64(b), nil
+	case flagFloat:
+		b, err := d.next(flag, 8)
+		if err != nil {
+			return nil, err
+		}
+		u := binary.BigEndian.Uint64(b)
+		if u&signMask != 0 {
+			u &= ^signMask
+		} else {
+			u = ^u
+		}
+		return math.Float64frombits(u), nil
+	default:
+		return nil, newErrFlagInvalid(flag)
+	}
+}
+
+func (d *decoder) decodeBytes(flag byte) ([]byte, error) {
+	data := []byte{}
+	for {
+		group, err := d.next(flag, groupSize+1)
+		if err != nil {
+			return nil, err
+		}
+		padCount := int(groupMarker - group[groupSize])
+		if groupSize < padCount {
+			return nil, newErrGroupInvalid(group)
+		}
+		realSize := groupSize - padCount
+		data = append(data, group[:realSize]...)
+		if padCount == 0 {
+			continue
+		}
+		for _, b := range group[realSize:groupSize] {
+			if b != groupPad {
+				return nil, newErrGroupInvalid(group)
+			}
+		}
+		return data, nil
+	}
+}
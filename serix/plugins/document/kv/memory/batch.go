@@ -0,0 +1,53 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+type batchOp struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// Batch represents a set of writes applied to the store atomically.
+type Batch struct {
+	ops []batchOp
+}
+
+// NewBatch returns a new empty batch.
+func NewBatch() *Batch {
+	return &Batch{
+		ops: []batchOp{},
+	}
+}
+
+// Set adds a write of the specified key and value to the batch.
+func (batch *Batch) Set(key []byte, value []byte) {
+	batch.ops = append(batch.ops, batchOp{key: clone(key), value: clone(value)})
+}
+
+// Delete adds a deletion of the specified key to the batch.
+func (batch *Batch) Delete(key []byte) {
+	batch.ops = append(batch.ops, batchOp{key: clone(key), delete: true})
+}
+
+// Len returns the number of the writes in the batch.
+func (batch *Batch) Len() int {
+	return len(batch.ops)
+}
+
+// Reset removes all writes from the batch.
+func (batch *Batch) Reset() {
+	batch.ops = batch.ops[:0]
+}
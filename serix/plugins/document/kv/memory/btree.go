@@ -0,0 +1,285 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"bytes"
+	"sort"
+)
+
+// The store keeps the items in a copy-on-write B-tree: every write clones the nodes on the path from
+// the root instead of modifying them, so that a snapshot is just the root node at the time and is never
+// affected by the later writes.
+
+const (
+	btreeDegree   = 32
+	btreeMaxItems = btreeDegree*2 - 1
+	btreeMinItems = btreeMaxItems / 2
+)
+
+type item struct {
+	key     []byte
+	value   []byte
+	version uint64
+}
+
+type node struct {
+	items    []item
+	children []*node
+}
+
+type btree struct {
+	root   *node
+	length int
+}
+
+func (n *node) isLeaf() bool {
+	return len(n.children) == 0
+}
+
+// clone returns a copy of the node which can be modified without affecting the other trees sharing the node.
+func (n *node) clone() *node {
+	c := &node{
+		items: make([]item, len(n.items), len(n.items)+1),
+	}
+	copy(c.items, n.items)
+	if !n.isLeaf() {
+		c.children = make([]*node, len(n.children), len(n.children)+1)
+		copy(c.children, n.children)
+	}
+	return c
+}
+
+// find returns the index of the first item whose key is greater than or equal to the specified key,
+// and whether the key is found at the index.
+func (n *node) find(key []byte) (int, bool) {
+	i := sort.Search(len(n.items), func(i int) bool {
+		return bytes.Compare(key, n.items[i].key) <= 0
+	})
+	return i, i < len(n.items) && bytes.Equal(n.items[i].key, key)
+}
+
+func (n *node) mutableChild(i int) *node {
+	c := n.children[i].clone()
+	n.children[i] = c
+	return c
+}
+
+// split splits the node at the specified index, and returns the item at the index and the new node with the rest.
+func (n *node) split(i int) (item, *node) {
+	it := n.items[i]
+	next := &node{
+		items: append([]item{}, n.items[i+1:]...),
+	}
+	n.items = n.items[:i]
+	if !n.isLeaf() {
+		next.children = append([]*node{}, n.children[i+1:]...)
+		n.children = n.children[:i+1]
+	}
+	return it, next
+}
+
+// maybeSplitChild splits the specified child if it is full, and returns true if the child is split.
+func (n *node) maybeSplitChild(i int) bool {
+	if len(n.children[i].items) < btreeMaxItems {
+		return false
+	}
+	first := n.mutableChild(i)
+	it, second := first.split(btreeMaxItems / 2)
+	n.items = insertAt(n.items, i, it)
+	n.children = insertAt(n.children, i+1, second)
+	return true
+}
+
+// insert inserts the specified item into the node, and returns true if an existing item is replaced.
+func (n *node) insert(it item) bool {
+	i, found := n.find(it.key)
+	if found {
+		n.items[i] = it
+		return true
+	}
+	if n.isLeaf() {
+		n.items = insertAt(n.items, i, it)
+		return false
+	}
+	if n.maybeSplitChild(i) {
+		switch cmp := bytes.Compare(it.key, n.items[i].key); {
+		case cmp == 0:
+			n.items[i] = it
+			return true
+		case 0 < cmp:
+			i++
+		}
+	}
+	return n.mutableChild(i).insert(it)
+}
+
+type removeType int
+
+const (
+	removeItem removeType = iota
+	removeMax
+)
+
+// remove removes the item of the specified key from the node, and returns the removed item.
+func (n *node) remove(key []byte, typ removeType) (item, bool) {
+	var i int
+	var found bool
+	switch typ {
+	case removeMax:
+		if n.isLeaf() {
+			it := n.items[len(n.items)-1]
+			n.items = n.items[:len(n.items)-1]
+			return it, true
+		}
+		i = len(n.items)
+	case removeItem:
+		i, found = n.find(key)
+		if n.isLeaf() {
+			if !found {
+				return item{}, false
+			}
+			it := n.items[i]
+			n.items = removeAt(n.items, i)
+			return it, true
+		}
+	}
+	// Make sure that the child has enough items to remove one
+	if len(n.children[i].items) <= btreeMinItems {
+		n.growChild(i)
+		return n.remove(key, typ)
+	}
+	child := n.mutableChild(i)
+	if found {
+		// Replace the item with its predecessor, the rightmost item of the left child
+		it := n.items[i]
+		n.items[i], _ = child.remove(nil, removeMax)
+		return it, true
+	}
+	return child.remove(key, typ)
+}
+
+// growChild moves an item into the specified child by stealing from or merging with its siblings.
+func (n *node) growChild(i int) {
+	switch {
+	case 0 < i && btreeMinItems < len(n.children[i-1].items):
+		// Steal from the left child
+		child := n.mutableChild(i)
+		left := n.mutableChild(i - 1)
+		child.items = insertAt(child.items, 0, n.items[i-1])
+		n.items[i-1] = left.items[len(left.items)-1]
+		left.items = left.items[:len(left.items)-1]
+		if !left.isLeaf() {
+			child.children = insertAt(child.children, 0, left.children[len(left.children)-1])
+			left.children = left.children[:len(left.children)-1]
+		}
+	case i < len(n.items) && btreeMinItems < len(n.children[i+1].items):
+		// Steal from the right child
+		child := n.mutableChild(i)
+		right := n.mutableChild(i + 1)
+		child.items = append(child.items, n.items[i])
+		n.items[i] = right.items[0]
+		right.items = removeAt(right.items, 0)
+		if !right.isLeaf() {
+			child.children = append(child.children, right.children[0])
+			right.children = removeAt(right.children, 0)
+		}
+	default:
+		// Merge with the right child
+		if len(n.items) <= i {
+			i--
+		}
+		child := n.mutableChild(i)
+		right := n.children[i+1]
+		child.items = append(child.items, n.items[i])
+		child.items = append(child.items, right.items...)
+		child.children = append(child.children, right.children...)
+		n.items = removeAt(n.items, i)
+		n.children = removeAt(n.children, i+1)
+	}
+}
+
+func insertAt[T any](s []T, i int, v T) []T {
+	var zero T
+	s = append(s, zero)
+	copy(s[i+1:], s[i:])
+	s[i] = v
+	return s
+}
+
+func removeAt[T any](s []T, i int) []T {
+	copy(s[i:], s[i+1:])
+	var zero T
+	s[len(s)-1] = zero
+	return s[:len(s)-1]
+}
+
+// get returns the item of the specified key.
+func (t *btree) get(key []byte) (item, bool) {
+	for n := t.root; n != nil; {
+		i, found := n.find(key)
+		if found {
+			return n.items[i], true
+		}
+		if n.isLeaf() {
+			break
+		}
+		n = n.children[i]
+	}
+	return item{}, false
+}
+
+// set sets the specified item, and returns true if an existing item is replaced.
+func (t *btree) set(it item) bool {
+	if t.root == nil {
+		t.root = &node{items: []item{it}}
+		t.length++
+		return false
+	}
+	t.root = t.root.clone()
+	if btreeMaxItems <= len(t.root.items) {
+		first := t.root
+		second, next := first.split(btreeMaxItems / 2)
+		t.root = &node{
+			items:    []item{second},
+			children: []*node{first, next},
+		}
+	}
+	replaced := t.root.insert(it)
+	if !replaced {
+		t.length++
+	}
+	return replaced
+}
+
+// delete deletes the item of the specified key, and returns true if the item is deleted.
+func (t *btree) delete(key []byte) bool {
+	if t.root == nil {
+		return false
+	}
+	if _, ok := t.get(key); !ok {
+		return false
+	}
+	t.root = t.root.clone()
+	t.root.remove(key, removeItem)
+	switch {
+	case len(t.root.items) == 0 && !t.root.isLeaf():
+		t.root = t.root.children[0]
+	case len(t.root.items) == 0:
+		t.root = nil
+	}
+	t.length--
+	return true
+}
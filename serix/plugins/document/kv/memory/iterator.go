@@ -0,0 +1,153 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"bytes"
+)
+
+type frame struct {
+	n *node
+	i int
+}
+
+// Iterator represents an iterator over the items of a key range in a snapshot.
+type Iterator struct {
+	stack   []frame
+	begin   []byte
+	end     []byte
+	reverse bool
+	item    item
+}
+
+func newIterator(root *node, begin []byte, end []byte, reverse bool) *Iterator {
+	it := &Iterator{
+		stack:   []frame{},
+		begin:   begin,
+		end:     end,
+		reverse: reverse,
+	}
+	if reverse {
+		it.seekLast(root)
+	} else {
+		it.seekFirst(root)
+	}
+	return it
+}
+
+// seekFirst positions the iterator before the first item whose key is greater than or equal to the begin key.
+func (it *Iterator) seekFirst(root *node) {
+	for n := root; n != nil; {
+		i := 0
+		if it.begin != nil {
+			i, _ = n.find(it.begin)
+		}
+		it.stack = append(it.stack, frame{n: n, i: i})
+		if n.isLeaf() {
+			break
+		}
+		n = n.children[i]
+	}
+}
+
+// seekLast positions the iterator after the last item whose key is less than the end key.
+func (it *Iterator) seekLast(root *node) {
+	for n := root; n != nil; {
+		i := len(n.items)
+		if it.end != nil {
+			i, _ = n.find(it.end)
+		}
+		it.stack = append(it.stack, frame{n: n, i: i})
+		if n.isLeaf() {
+			break
+		}
+		n = n.children[i]
+	}
+}
+
+func (it *Iterator) next() (item, bool) {
+	for 0 < len(it.stack) {
+		top := &it.stack[len(it.stack)-1]
+		if len(top.n.items) <= top.i {
+			it.stack = it.stack[:len(it.stack)-1]
+			continue
+		}
+		item := top.n.items[top.i]
+		top.i++
+		if !top.n.isLeaf() {
+			for n := top.n.children[top.i]; n != nil; n = n.children[0] {
+				it.stack = append(it.stack, frame{n: n, i: 0})
+				if n.isLeaf() {
+					break
+				}
+			}
+		}
+		return item, true
+	}
+	return item{}, false
+}
+
+func (it *Iterator) prev() (item, bool) {
+	for 0 < len(it.stack) {
+		top := &it.stack[len(it.stack)-1]
+		if top.i <= 0 {
+			it.stack = it.stack[:len(it.stack)-1]
+			continue
+		}
+		top.i--
+		item := top.n.items[top.i]
+		if !top.n.isLeaf() {
+			for n := top.n.children[top.i]; n != nil; n = n.children[len(n.children)-1] {
+				it.stack = append(it.stack, frame{n: n, i: len(n.items)})
+				if n.isLeaf() {
+					break
+				}
+			}
+		}
+		return item, true
+	}
+	return item{}, false
+}
+
+// Next advances the iterator to the next item, and returns false if there are no more items in the range.
+func (it *Iterator) Next() bool {
+	var ok bool
+	if it.reverse {
+		it.item, ok = it.prev()
+		if ok && it.begin != nil && bytes.Compare(it.item.key, it.begin) < 0 {
+			ok = false
+		}
+	} else {
+		it.item, ok = it.next()
+		if ok && it.end != nil && 0 <= bytes.Compare(it.item.key, it.end) {
+			ok = false
+		}
+	}
+	if !ok {
+		it.stack = it.stack[:0]
+		it.item = item{}
+	}
+	return ok
+}
+
+// Key returns the key of the current item. The returned key must not be modified.
+func (it *Iterator) Key() []byte {
+	return it.item.key
+}
+
+// Value returns the value of the current item. The returned value must not be modified.
+func (it *Iterator) Value() []byte {
+	return it.item.value
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"github.com/cybergarage/go-serix/serix/document"
+	"github.com/cybergarage/go-serix/serix/document/kv"
+)
+
+// Snapshot represents a read-only view of the store at a point in time.
+// A snapshot is not affected by the writes to the store after it is taken.
+type Snapshot struct {
+	tree btree
+}
+
+// Get returns the value of the specified key. The returned value must not be modified.
+func (snap *Snapshot) Get(key []byte) ([]byte, error) {
+	it, ok := snap.tree.get(key)
+	if !ok {
+		return nil, newErrKeyNotExist(key)
+	}
+	return it.value, nil
+}
+
+// Len returns the number of the items in the snapshot.
+func (snap *Snapshot) Len() int {
+	return snap.tree.length
+}
+
+// Range returns an iterator over the items whose keys are in [begin, end) in the specified order.
+// A nil begin or end means that the range is unbounded on the side.
+func (snap *Snapshot) Range(begin []byte, end []byte, order document.Order) (kv.Iterator, error) {
+	return newIterator(snap.tree.root, begin, end, order == document.Descending), nil
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"sync"
+
+	"github.com/cybergarage/go-serix/serix/document"
+	"github.com/cybergarage/go-serix/serix/document/kv"
+)
+
+// Store represents an in-memory ordered key-value store.
+// The items are sorted by the byte order of their encoded keys, and the readers can take snapshots
+// which are isolated from the later writes without blocking the writers.
+type Store struct {
+	mutex   sync.RWMutex
+	tree    btree
+	version uint64
+}
+
+// NewStore returns a new empty in-memory store.
+func NewStore() *Store {
+	return &Store{
+		mutex:   sync.RWMutex{},
+		tree:    btree{},
+		version: 0,
+	}
+}
+
+// Snapshot returns a read-only snapshot of the current items.
+func (store *Store) Snapshot() *Snapshot {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+	return &Snapshot{tree: store.tree}
+}
+
+// Get returns the value of the specified key. The returned value must not be modified.
+func (store *Store) Get(key []byte) ([]byte, error) {
+	return store.Snapshot().Get(key)
+}
+
+// Set sets the specified value to the key. The key and value are copied.
+func (store *Store) Set(key []byte, value []byte) error {
+	key = clone(key)
+	value = clone(value)
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	store.version++
+	store.tree.set(item{key: key, value: value, version: store.version})
+	return nil
+}
+
+// Delete deletes the specified key, and returns an error if the key does not exist.
+func (store *Store) Delete(key []byte) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if !store.tree.delete(key) {
+		return newErrKeyNotExist(key)
+	}
+	store.version++
+	return nil
+}
+
+// Write applies all writes in the specified batch atomically.
+// The snapshots taken by the readers see either all of the writes or none of them.
+func (store *Store) Write(batch *Batch) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	store.version++
+	for _, op := range batch.ops {
+		if op.delete {
+			store.tree.delete(op.key)
+			continue
+		}
+		store.tree.set(item{key: op.key, value: op.value, version: store.version})
+	}
+}
+
+// Transact begins a new read-write transaction if the write flag is true, otherwise a new read-only transaction.
+func (store *Store) Transact(write bool) (kv.Transaction, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+	return newTransaction(store, write), nil
+}
+
+// Len returns the number of the items in the store.
+func (store *Store) Len() int {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+	return store.tree.length
+}
+
+// Range returns an iterator over the items whose keys are in [begin, end) in the specified order
+// on a snapshot of the current items.
+func (store *Store) Range(begin []byte, end []byte, order document.Order) (kv.Iterator, error) {
+	return store.Snapshot().Range(begin, end, order)
+}
+
+func clone(b []byte) []byte {
+	if b == nil {
+		return []byte{}
+	}
+	return append(make([]byte, 0, len(b)), b...)
+}
@@ -0,0 +1,268 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/cybergarage/go-serix/serix/document"
+	"github.com/cybergarage/go-serix/serix/document/kv"
+)
+
+func testKey(n int) []byte {
+	return binary.BigEndian.AppendUint32(nil, uint32(n))
+}
+
+func sortedKeys(m map[string][]byte) [][]byte {
+	keys := make([][]byte, 0, len(m))
+	for k := range m {
+		keys = append(keys, []byte(k))
+	}
+	slices.SortFunc(keys, bytes.Compare)
+	return keys
+}
+
+func rangeKeys(it kv.Iterator, err error) ([][]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	keys := [][]byte{}
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	return keys, nil
+}
+
+func assertRange(t *testing.T, snap *Snapshot, m map[string][]byte, begin []byte, end []byte) {
+	t.Helper()
+	expected := [][]byte{}
+	for _, k := range sortedKeys(m) {
+		if begin != nil && bytes.Compare(k, begin) < 0 {
+			continue
+		}
+		if end != nil && 0 <= bytes.Compare(k, end) {
+			continue
+		}
+		expected = append(expected, k)
+	}
+	keys, err := rangeKeys(snap.Range(begin, end, document.Ascending))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.EqualFunc(keys, expected, bytes.Equal) {
+		t.Errorf("range [% x, % x): %d keys != %d keys", begin, end, len(keys), len(expected))
+	}
+	slices.Reverse(expected)
+	keys, err = rangeKeys(snap.Range(begin, end, document.Descending))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.EqualFunc(keys, expected, bytes.Equal) {
+		t.Errorf("reverse range [% x, % x): %d keys != %d keys", begin, end, len(keys), len(expected))
+	}
+}
+
+func TestStore(t *testing.T) {
+	const keyRange = 2000
+	store := NewStore()
+	m := map[string][]byte{}
+	r := rand.New(rand.NewSource(1))
+
+	for n := range 20000 {
+		key := testKey(r.Intn(keyRange))
+		if r.Intn(3) == 0 {
+			_, ok := m[string(key)]
+			err := store.Delete(key)
+			if ok != (err == nil) {
+				t.Fatalf("delete % x: %v", key, err)
+			}
+			delete(m, string(key))
+		} else {
+			value := testKey(n)
+			if err := store.Set(key, value); err != nil {
+				t.Fatal(err)
+			}
+			m[string(key)] = value
+		}
+		if store.Len() != len(m) {
+			t.Fatalf("%d != %d", store.Len(), len(m))
+		}
+	}
+
+	for n := range keyRange {
+		key := testKey(n)
+		value, err := store.Get(key)
+		expected, ok := m[string(key)]
+		switch {
+		case !ok:
+			if !errors.Is(err, document.ErrNotExist) {
+				t.Errorf("get % x: %v", key, err)
+			}
+		case err != nil:
+			t.Error(err)
+		case !bytes.Equal(value, expected):
+			t.Errorf("get % x: % x != % x", key, value, expected)
+		}
+	}
+
+	snap := store.Snapshot()
+	bounds := [][2][]byte{
+		{nil, nil},
+		{testKey(100), nil},
+		{nil, testKey(1500)},
+		{testKey(500), testKey(501)},
+		{testKey(700), testKey(1300)},
+		{testKey(1300), testKey(700)},
+		{[]byte{0x00, 0x00, 0x03}, []byte{0x00, 0x00, 0x04, 0xFF, 0xFF}},
+	}
+	for _, b := range bounds {
+		assertRange(t, snap, m, b[0], b[1])
+	}
+}
+
+func TestStoreSnapshot(t *testing.T) {
+	store := NewStore()
+	m := map[string][]byte{}
+	for n := range 1000 {
+		if err := store.Set(testKey(n), testKey(n)); err != nil {
+			t.Fatal(err)
+		}
+		m[string(testKey(n))] = testKey(n)
+	}
+
+	snap := store.Snapshot()
+	for n := range 1000 {
+		if n%2 == 0 {
+			if err := store.Delete(testKey(n)); err != nil {
+				t.Fatal(err)
+			}
+		} else {
+			if err := store.Set(testKey(n), []byte("updated")); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := store.Set(testKey(5000), testKey(5000)); err != nil {
+		t.Fatal(err)
+	}
+
+	if snap.Len() != len(m) {
+		t.Errorf("%d != %d", snap.Len(), len(m))
+	}
+	for n := range 1000 {
+		value, err := snap.Get(testKey(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(value, testKey(n)) {
+			t.Errorf("% x != % x", value, testKey(n))
+		}
+	}
+	assertRange(t, snap, m, nil, nil)
+
+	if store.Len() != 501 {
+		t.Errorf("%d != %d", store.Len(), 501)
+	}
+}
+
+func TestStoreCopiesBytes(t *testing.T) {
+	store := NewStore()
+	key := []byte("key")
+	value := []byte("value")
+	if err := store.Set(key, value); err != nil {
+		t.Fatal(err)
+	}
+	key[0] = 'x'
+	value[0] = 'x'
+	v, err := store.Get([]byte("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(v, []byte("value")) {
+		t.Errorf("%s != %s", v, "value")
+	}
+}
+
+func TestStoreBatch(t *testing.T) {
+	store := NewStore()
+	for n := range 100 {
+		if err := store.Set(testKey(n), testKey(0)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Concurrent readers must see all writes of a batch or none of them.
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				it, err := store.Range(nil, nil, document.Ascending)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				var first []byte
+				for it.Next() {
+					if first == nil {
+						first = it.Value()
+						continue
+					}
+					if !bytes.Equal(it.Value(), first) {
+						t.Errorf("% x: % x != % x", it.Key(), it.Value(), first)
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	batch := NewBatch()
+	for v := 1; v <= 100; v++ {
+		batch.Reset()
+		for n := range 100 {
+			batch.Set(testKey(n), testKey(v))
+		}
+		store.Write(batch)
+	}
+	close(done)
+	wg.Wait()
+
+	batch.Reset()
+	for n := range 50 {
+		batch.Delete(testKey(n))
+	}
+	store.Write(batch)
+	keys, err := rangeKeys(store.Range(nil, nil, document.Ascending))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 50 || !bytes.Equal(keys[0], testKey(50)) {
+		t.Errorf("%d keys from % x", len(keys), keys[0])
+	}
+}
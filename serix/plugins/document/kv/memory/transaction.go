@@ -0,0 +1,147 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"github.com/cybergarage/go-serix/serix/document"
+	"github.com/cybergarage/go-serix/serix/document/kv"
+)
+
+// Transaction represents a transaction of the in-memory store with snapshot isolation.
+// The transaction writes to its own copy-on-write tree of the snapshot, and the commit fails with
+// an error wrapping kv.ErrConflict if another transaction has committed a write to the same key since the snapshot.
+type Transaction struct {
+	store   *Store
+	base    btree
+	tree    btree
+	written map[string]struct{}
+	write   bool
+	closed  bool
+}
+
+func newTransaction(store *Store, write bool) *Transaction {
+	return &Transaction{
+		store:   store,
+		base:    store.tree,
+		tree:    store.tree,
+		written: map[string]struct{}{},
+		write:   write,
+		closed:  false,
+	}
+}
+
+// IsReadOnly returns true if the transaction is read-only.
+func (tx *Transaction) IsReadOnly() bool {
+	return !tx.write
+}
+
+// Get returns the value of the specified key. The returned value must not be modified.
+func (tx *Transaction) Get(key []byte) ([]byte, error) {
+	if tx.closed {
+		return nil, kv.NewErrTransactionClosed()
+	}
+	it, ok := tx.tree.get(key)
+	if !ok {
+		return nil, newErrKeyNotExist(key)
+	}
+	return it.value, nil
+}
+
+// Set sets the specified value to the key. The key and value are copied.
+func (tx *Transaction) Set(key []byte, value []byte) error {
+	if err := tx.checkWritable(); err != nil {
+		return err
+	}
+	key = clone(key)
+	tx.tree.set(item{key: key, value: clone(value), version: 0})
+	tx.written[string(key)] = struct{}{}
+	return nil
+}
+
+// Delete deletes the specified key, and returns an error if the key does not exist.
+func (tx *Transaction) Delete(key []byte) error {
+	if err := tx.checkWritable(); err != nil {
+		return err
+	}
+	if !tx.tree.delete(key) {
+		return newErrKeyNotExist(key)
+	}
+	tx.written[string(key)] = struct{}{}
+	return nil
+}
+
+// Range returns an iterator over the items whose keys are in [begin, end) in the specified order,
+// including the writes of the transaction before the call.
+func (tx *Transaction) Range(begin []byte, end []byte, order document.Order) (kv.Iterator, error) {
+	if tx.closed {
+		return nil, kv.NewErrTransactionClosed()
+	}
+	return newIterator(tx.tree.root, begin, end, order == document.Descending), nil
+}
+
+// Commit applies all writes of the transaction to the store atomically, and closes the transaction.
+func (tx *Transaction) Commit() error {
+	if tx.closed {
+		return kv.NewErrTransactionClosed()
+	}
+	tx.closed = true
+	if len(tx.written) == 0 {
+		return nil
+	}
+
+	store := tx.store
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	// First committer wins: the written keys must not be changed since the snapshot
+	for key := range tx.written {
+		baseItem, baseOk := tx.base.get([]byte(key))
+		storeItem, storeOk := store.tree.get([]byte(key))
+		if baseOk != storeOk || baseItem.version != storeItem.version {
+			return kv.NewErrTransactionConflict([]byte(key))
+		}
+	}
+
+	store.version++
+	for key := range tx.written {
+		it, ok := tx.tree.get([]byte(key))
+		if !ok {
+			store.tree.delete([]byte(key))
+			continue
+		}
+		it.version = store.version
+		store.tree.set(it)
+	}
+	return nil
+}
+
+// Cancel discards all writes of the transaction, and closes the transaction.
+func (tx *Transaction) Cancel() error {
+	if tx.closed {
+		return kv.NewErrTransactionClosed()
+	}
+	tx.closed = true
+	return nil
+}
+
+func (tx *Transaction) checkWritable() error {
+	if tx.closed {
+		return kv.NewErrTransactionClosed()
+	}
+	if !tx.write {
+		return kv.NewErrTransactionReadOnly()
+	}
+	return nil
+}
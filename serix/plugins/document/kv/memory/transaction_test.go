@@ -0,0 +1,107 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-serix/serix/document/kv"
+	"github.com/cybergarage/go-serix/serixtest"
+)
+
+func TestStoreSuite(t *testing.T) {
+	store := NewStore()
+	if err := store.Set([]byte("other"), []byte("other")); err != nil {
+		t.Fatal(err)
+	}
+	serixtest.StoreSuite(t, store)
+}
+
+func TestTransactionConflict(t *testing.T) {
+	store := NewStore()
+	k1 := []byte("k1")
+	k2 := []byte("k2")
+	if err := store.Set(k1, []byte("v1")); err != nil {
+		t.Fatal(err)
+	}
+
+	conflicts := []struct {
+		name  string
+		first func(tx kv.Transaction) error
+		next  func(tx kv.Transaction) error
+		err   bool
+	}{
+		{
+			"same key",
+			func(tx kv.Transaction) error { return tx.Set(k1, []byte("a")) },
+			func(tx kv.Transaction) error { return tx.Set(k1, []byte("b")) },
+			true,
+		},
+		{
+			"deleted key",
+			func(tx kv.Transaction) error { return tx.Delete(k1) },
+			func(tx kv.Transaction) error { return tx.Set(k1, []byte("b")) },
+			true,
+		},
+		{
+			"inserted key",
+			func(tx kv.Transaction) error { return tx.Set(k2, []byte("a")) },
+			func(tx kv.Transaction) error { return tx.Set(k2, []byte("b")) },
+			true,
+		},
+		{
+			"other key",
+			func(tx kv.Transaction) error { return tx.Set(k1, []byte("a")) },
+			func(tx kv.Transaction) error { return tx.Delete(k2) },
+			false,
+		},
+	}
+
+	for _, c := range conflicts {
+		t.Run(c.name, func(t *testing.T) {
+			first, err := store.Transact(true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			next, err := store.Transact(true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := c.first(first); err != nil {
+				t.Fatal(err)
+			}
+			if err := c.next(next); err != nil {
+				t.Fatal(err)
+			}
+			if err := first.Commit(); err != nil {
+				t.Fatal(err)
+			}
+			err = next.Commit()
+			if c.err != errors.Is(err, kv.ErrConflict) {
+				t.Errorf("%v", err)
+			}
+		})
+	}
+
+	// The writes of the conflicted transaction are discarded
+	v, err := store.Get(k1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(v) != "a" {
+		t.Errorf("%s != %s", v, "a")
+	}
+}
@@ -15,11 +15,70 @@
 package gzip
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/cybergarage/go-serix/serix/document"
+	"github.com/cybergarage/go-serix/serix/plugins/document/object/cbor"
 	"github.com/cybergarage/go-serix/serixtest"
 )
 
 func TestGzipCoder(t *testing.T) {
 	serixtest.ObjectCompressorSuite(t, NewCoder())
 }
+
+func TestGzipCoderSchema(t *testing.T) {
+	s1 := document.NewSchema()
+	s1.SetName("users")
+	e1 := document.NewElement().SetName("id").SetType(document.Int64Type)
+	e2 := document.NewElement().SetName("name").SetType(document.StringType)
+	s1.AddElement(e1)
+	s1.AddElement(e2)
+	pk := document.NewIndex().SetName("pk").SetType(document.PrimaryIndex).AddElement(e1)
+	idx := document.NewIndex().SetName("name").SetType(document.SecondaryIndex).AddElement(e2).
+		SetElementOrder("name", document.Descending).
+		SetElementNullOrder("name", document.NullsFirst).
+		SetElementCollation("name", document.CaseFoldedCollation)
+	s1.AddIndex(pk)
+	s1.AddIndex(idx)
+
+	coder := document.NewChainCorder(cbor.NewCoder(), NewCoder())
+	var w bytes.Buffer
+	if err := coder.EncodeObject(&w, s1.Data()); err != nil {
+		t.Fatal(err)
+	}
+	obj, err := coder.DecodeObject(&w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2, err := document.NewSchemaWith(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idx2, err := s2.FindIndex("name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order := idx2.ElementOrder("name"); order != document.Descending {
+		t.Errorf("%v != %v", order, document.Descending)
+	}
+	if nulls := idx2.ElementNullOrder("name"); nulls != document.NullsFirst {
+		t.Errorf("%v != %v", nulls, document.NullsFirst)
+	}
+	if c := idx2.ElementCollation("name"); c != document.CaseFoldedCollation {
+		t.Errorf("%v != %v", c, document.CaseFoldedCollation)
+	}
+
+	k1, err := document.NewIndexKeyWith(idx, "Alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	k2, err := document.NewIndexKeyWith(idx2, "Alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmp, err := k1.Compare(k2); err != nil || cmp != 0 {
+		t.Errorf("%v != %v (%v)", k2, k1, err)
+	}
+}
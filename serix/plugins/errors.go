@@ -0,0 +1,34 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugins
+
+import (
+	"fmt"
+
+	"github.com/cybergarage/go-serix/serix/document"
+	"github.com/cybergarage/go-serix/serix/document/kv"
+)
+
+func newErrObjectCoderNotExist(name string) error {
+	return fmt.Errorf("object coder (%s) is %w", name, document.ErrNotExist)
+}
+
+func newErrDocumentTypeNotSupported(t kv.DocumentType) error {
+	return fmt.Errorf("document type (%d) is %w", t, document.ErrNotSupported)
+}
+
+func newErrKeyHeaderNotSupported(header kv.KeyHeader) error {
+	return fmt.Errorf("key header (%s) is %w", header.String(), document.ErrNotSupported)
+}
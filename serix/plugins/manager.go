@@ -16,6 +16,7 @@ package plugins
 
 import (
 	"github.com/cybergarage/go-serix/serix/document"
+	"github.com/cybergarage/go-serix/serix/document/kv"
 )
 
 // Manager represents a plugin manager.
@@ -24,4 +25,13 @@ type Manager interface {
 	KeyCoders() []document.KeyCoder
 	// ObjectCoders returns the registered object coders.
 	ObjectCoders() []document.ObjectCoder
+	// ObjectCoder returns the registered object coder of the specified name.
+	ObjectCoder(name string) (document.ObjectCoder, error)
+	// ObjectCoderForDocumentType returns the object coder of the specified document type, chaining
+	// the registered serializer and compressor if the document type is compressed.
+	ObjectCoderForDocumentType(t kv.DocumentType) (document.ObjectCoder, error)
+	// ObjectCoderForHeader returns the object coder of the document type in the specified key header.
+	ObjectCoderForHeader(header kv.KeyHeader) (document.ObjectCoder, error)
+	// DocumentTypeForObjectCoder returns the document type of the specified object coder.
+	DocumentTypeForObjectCoder(coder document.ObjectCoder) (kv.DocumentType, error)
 }
@@ -16,7 +16,10 @@ package plugins
 
 import (
 	"github.com/cybergarage/go-serix/serix/document"
+	"github.com/cybergarage/go-serix/serix/document/kv"
 	"github.com/cybergarage/go-serix/serix/plugins/document/key/composite"
+	"github.com/cybergarage/go-serix/serix/plugins/document/key/fdbtuple"
+	"github.com/cybergarage/go-serix/serix/plugins/document/key/memcomparable"
 	"github.com/cybergarage/go-serix/serix/plugins/document/object/cbor"
 	"github.com/cybergarage/go-serix/serix/plugins/document/object/gob"
 	"github.com/cybergarage/go-serix/serix/plugins/document/object/gzip"
@@ -33,6 +36,8 @@ type manager struct {
 func NewManager() Manager {
 	keyCoders := []document.KeyCoder{
 		composite.NewCoder(),
+		fdbtuple.NewCoder(),
+		memcomparable.NewCoder(),
 	}
 	objCoders := []document.ObjectCoder{
 		cbor.NewCoder(),
@@ -55,3 +60,58 @@ func (m *manager) KeyCoders() []document.KeyCoder {
 func (m *manager) ObjectCoders() []document.ObjectCoder {
 	return m.objectCoders
 }
+
+// ObjectCoder returns the registered object coder of the specified name.
+func (m *manager) ObjectCoder(name string) (document.ObjectCoder, error) {
+	for _, coder := range m.objectCoders {
+		if coder.Name() == name {
+			return coder, nil
+		}
+	}
+	return nil, newErrObjectCoderNotExist(name)
+}
+
+// ObjectCoderForDocumentType returns the object coder of the specified document type, chaining
+// the registered serializer and compressor if the document type is compressed.
+func (m *manager) ObjectCoderForDocumentType(t kv.DocumentType) (document.ObjectCoder, error) {
+	names := t.CoderNames()
+	if len(names) == 0 {
+		return nil, newErrDocumentTypeNotSupported(t)
+	}
+	coders := []document.ObjectCoder{}
+	for _, name := range names {
+		coder, err := m.ObjectCoder(name)
+		if err != nil {
+			return nil, err
+		}
+		coders = append(coders, coder)
+	}
+	if len(coders) == 1 {
+		return coders[0], nil
+	}
+	return document.NewChainCorder(coders...), nil
+}
+
+// ObjectCoderForHeader returns the object coder of the document type in the specified key header.
+func (m *manager) ObjectCoderForHeader(header kv.KeyHeader) (document.ObjectCoder, error) {
+	switch header.Type() {
+	case kv.DatabaseObject, kv.CollectionObject, kv.DocumentObject:
+		return m.ObjectCoderForDocumentType(header.DocumentType())
+	default:
+		// Index keys have no stored documents
+		return nil, newErrKeyHeaderNotSupported(header)
+	}
+}
+
+// DocumentTypeForObjectCoder returns the document type of the specified object coder.
+func (m *manager) DocumentTypeForObjectCoder(coder document.ObjectCoder) (kv.DocumentType, error) {
+	t, err := kv.DocumentTypeForObjectCoder(coder)
+	if err != nil {
+		return 0, err
+	}
+	// The object coders of the document type must be registered
+	if _, err := m.ObjectCoderForDocumentType(t); err != nil {
+		return 0, err
+	}
+	return t, nil
+}
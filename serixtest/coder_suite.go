@@ -26,6 +26,19 @@ import (
 // KeyCoderSuite tests the encoding and decoding of keys using the provided KeyCoder.
 func KeyCoderSuite(t *testing.T, coder document.KeyCoder) {
 	t.Helper()
+	keyCoderSuite(t, coder, key.SortableKeyTest)
+}
+
+// IntegerLastKeyCoderSuite tests the given KeyCoder which encodes integers with markers sorting after
+// strings, byte strings and nested tuples, as the FoundationDB tuple layer does.
+func IntegerLastKeyCoderSuite(t *testing.T, coder document.KeyCoder) {
+	t.Helper()
+	keyCoderSuite(t, coder, key.IntegerLastSortableKeyTest)
+}
+
+// keyCoderSuite runs the key coder tests with the specified sortable key test.
+func keyCoderSuite(t *testing.T, coder document.KeyCoder, sortableKeyTest func(*testing.T, document.KeyCoder)) {
+	t.Helper()
 
 	tests := []struct {
 		name string
@@ -37,7 +50,23 @@ func KeyCoderSuite(t *testing.T, coder document.KeyCoder) {
 		},
 		{
 			name: "SortableKeyTest",
-			test: key.SortableKeyTest,
+			test: sortableKeyTest,
+		},
+		{
+			name: "IntegerKeyTest",
+			test: key.IntegerKeyTest,
+		},
+		{
+			name: "PrefixRangeKeyTest",
+			test: key.PrefixRangeKeyTest,
+		},
+		{
+			name: "AppendKeyTest",
+			test: key.AppendKeyTest,
+		},
+		{
+			name: "SchemaKeyTest",
+			test: key.SchemaKeyTest,
 		},
 	}
 
@@ -48,6 +77,57 @@ func KeyCoderSuite(t *testing.T, coder document.KeyCoder) {
 	}
 }
 
+// NumericKeyCoderSuite tests the given KeyCoder which encodes all numeric types with one ordered representation.
+// The coder does not need to preserve the numeric types, so the sortable tests check the decoded numbers by their values.
+func NumericKeyCoderSuite(t *testing.T, coder document.KeyCoder) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		test func(t *testing.T, coder document.KeyCoder)
+	}{
+		{
+			name: "RoundTripKeyTest",
+			test: key.RoundTripKeyTest,
+		},
+		{
+			name: "SortableKeyTest",
+			test: key.NumericSortableKeyTest,
+		},
+		{
+			name: "NumericOrderKeyTest",
+			test: key.NumericOrderKeyTest,
+		},
+		{
+			name: "SchemaKeyTest",
+			test: key.SchemaKeyTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, coder)
+		})
+	}
+}
+
+// FDBTupleKeyCoderSuite tests the given KeyCoder which is compatible with the FoundationDB tuple layer encoding.
+func FDBTupleKeyCoderSuite(t *testing.T, coder document.KeyCoder) {
+	t.Helper()
+
+	IntegerLastKeyCoderSuite(t, coder)
+
+	t.Run("KnownAnswerKeyTest", func(t *testing.T) {
+		key.KnownAnswerKeyTest(t, coder, key.FDBTupleKeyVectors())
+	})
+}
+
+// KeyCoderBenchmark benchmarks the encoding and decoding of keys using the provided KeyCoder.
+func KeyCoderBenchmark(b *testing.B, coder document.KeyCoder) {
+	b.Helper()
+	key.KeyCoderBenchmark(b, coder)
+}
+
 // ObjectSerializerSuite tests the specified document coder.
 func ObjectSerializerSuite(t *testing.T, coder document.ObjectCoder) {
 	t.Helper()
@@ -0,0 +1,110 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package key
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+// AppendKeyTest tests that the given coder appends the same encodings as EncodeKey,
+// and appends keys of the primitive types without any allocation when the buffer has enough capacity.
+func AppendKeyTest(t *testing.T, coder document.KeyCoder) {
+	t.Helper()
+
+	appender, ok := coder.(document.KeyAppender)
+	if !ok {
+		t.Skipf("%s: append is not supported", coder.Name())
+	}
+
+	keys := []document.Key{
+		document.NewKeyWith(),
+		document.NewKeyWith(nil),
+		document.NewKeyWith("users", int64(42)),
+		document.NewKeyWith("users", int64(-42), uint64(42), 1.5, true, false),
+		document.NewKeyWith("alice@example.com", []byte{0x00, 0x01, 0xFF}, "a\x00b"),
+	}
+
+	prefix := []byte{0x01, 0x02, 0x03}
+	for _, key := range keys {
+		encoded, err := coder.EncodeKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dst := append(make([]byte, 0, len(prefix)), prefix...)
+		appended, err := appender.AppendKey(dst, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(appended[:len(prefix)], prefix) || !bytes.Equal(appended[len(prefix):], encoded) {
+			t.Errorf("%v: % x != % x + % x", key, appended, prefix, encoded)
+		}
+
+		buf := make([]byte, 0, 256)
+		allocs := testing.AllocsPerRun(100, func() {
+			buf, err = appender.AppendKey(buf[:0], key)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if 0 < allocs {
+			t.Errorf("%v: AppendKey allocates %.1f times", key, allocs)
+		}
+	}
+}
+
+// KeyCoderBenchmark benchmarks the encoding and decoding of a typical index key using the provided KeyCoder.
+func KeyCoderBenchmark(b *testing.B, coder document.KeyCoder) {
+	b.Helper()
+
+	key := document.NewKeyWith("users", int64(42), "alice@example.com", []byte{0x00, 0x01, 0xFF}, 3.14, true)
+	encoded, err := coder.EncodeKey(key)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("EncodeKey", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := coder.EncodeKey(key); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	if appender, ok := coder.(document.KeyAppender); ok {
+		b.Run("AppendKey", func(b *testing.B) {
+			b.ReportAllocs()
+			buf := make([]byte, 0, len(encoded))
+			for b.Loop() {
+				buf, err = appender.AppendKey(buf[:0], key)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+
+	b.Run("DecodeKey", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := coder.DecodeKey(encoded); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,197 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package key
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+// FloatKeyTest tests the float canonicalization policy of the given coder: all NaN values have one
+// encoding which sorts after positive infinity, negative zero sorts before or equal to positive zero,
+// and float32 values sort numerically and decode back with their values.
+func FloatKeyTest(t *testing.T, coder document.KeyCoder) {
+	t.Helper()
+	floatKeyTest(t, coder, true)
+}
+
+func floatKeyTest(t *testing.T, coder document.KeyCoder, typed bool) {
+	t.Helper()
+
+	t.Run("nan", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			nans []any
+			inf  any
+		}{
+			{
+				name: "float64",
+				nans: []any{
+					math.NaN(),
+					math.Float64frombits(0x7FF8000000000000),
+					math.Float64frombits(0xFFF8000000000000),
+					math.Float64frombits(0x7FF0000000000001),
+					math.Float64frombits(0xFFFFFFFFFFFFFFFF),
+				},
+				inf: math.Inf(1),
+			},
+			{
+				name: "float32",
+				nans: []any{
+					float32(math.NaN()),
+					math.Float32frombits(0xFFC00000),
+					math.Float32frombits(0x7F800001),
+					math.Float32frombits(0xFFFFFFFF),
+				},
+				inf: float32(math.Inf(1)),
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				infEncoded := encodeSortableKey(t, coder, document.NewKeyWith(tc.inf))
+				nanEncoded := encodeSortableKey(t, coder, document.NewKeyWith(tc.nans[0]))
+				if bytes.Compare(infEncoded, nanEncoded) >= 0 {
+					t.Errorf("Sort order violation: %v (% x) should be < NaN (% x)", tc.inf, infEncoded, nanEncoded)
+				}
+				for _, nan := range tc.nans {
+					key := document.NewKeyWith(nan)
+					encoded := encodeSortableKey(t, coder, key)
+					if !bytes.Equal(encoded, nanEncoded) {
+						t.Errorf("NaN is not canonical: %v (% x) != % x", key, encoded, nanEncoded)
+					}
+					decKey, err := coder.DecodeKey(encoded)
+					if err != nil {
+						t.Fatalf("Decode failed for %v: %v", key, err)
+					}
+					if !key.Equal(decKey) {
+						t.Errorf("%v != %v", key, decKey)
+					}
+				}
+				if cmp, err := document.NewKeyWith(tc.nans[0]).Compare(document.NewKeyWith(tc.inf)); err != nil || cmp <= 0 {
+					t.Errorf("Key order violation: NaN should be > %v (cmp = %d, err = %v)", tc.inf, cmp, err)
+				}
+			})
+		}
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			values   []any
+			negZero  any
+			posZero  any
+			negative func(any) bool
+		}{
+			{
+				name:     "float64",
+				values:   []any{-math.SmallestNonzeroFloat64, math.SmallestNonzeroFloat64},
+				negZero:  math.Copysign(0, -1),
+				posZero:  float64(0),
+				negative: func(v any) bool { f, ok := v.(float64); return ok && math.Signbit(f) },
+			},
+			{
+				name:     "float32",
+				values:   []any{float32(-math.SmallestNonzeroFloat32), float32(math.SmallestNonzeroFloat32)},
+				negZero:  float32(math.Copysign(0, -1)),
+				posZero:  float32(0),
+				negative: func(v any) bool { f, ok := v.(float32); return ok && math.Signbit(float64(f)) },
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				below := encodeSortableKey(t, coder, document.NewKeyWith(tc.values[0]))
+				above := encodeSortableKey(t, coder, document.NewKeyWith(tc.values[1]))
+				negEncoded := encodeSortableKey(t, coder, document.NewKeyWith(tc.negZero))
+				posEncoded := encodeSortableKey(t, coder, document.NewKeyWith(tc.posZero))
+				if bytes.Compare(below, negEncoded) >= 0 || bytes.Compare(negEncoded, posEncoded) > 0 || bytes.Compare(posEncoded, above) >= 0 {
+					t.Errorf("Sort order violation: % x < % x <= % x < % x", below, negEncoded, posEncoded, above)
+				}
+
+				// Merged zeros decode as positive zero, and distinct zeros keep their signs
+				decKey, err := coder.DecodeKey(negEncoded)
+				if err != nil {
+					t.Fatalf("Decode failed for %v: %v", tc.negZero, err)
+				}
+				if !document.NewKeyWith(tc.negZero).Equal(decKey) {
+					t.Errorf("%v != %v", tc.negZero, decKey)
+				}
+				merged := bytes.Equal(negEncoded, posEncoded)
+				if tc.negative(decKey[0]) == merged {
+					t.Errorf("%v: unexpected sign of zero (merged = %t)", decKey, merged)
+				}
+			})
+		}
+	})
+
+	t.Run("float32", func(t *testing.T) {
+		// Values in expected sort order
+		values := []float32{
+			float32(math.Inf(-1)),
+			-math.MaxFloat32,
+			-1.5,
+			-math.SmallestNonzeroFloat32,
+			0,
+			math.SmallestNonzeroFloat32,
+			0.1,
+			1.5,
+			math.MaxFloat32,
+			float32(math.Inf(1)),
+		}
+
+		var encodings [][]byte
+		for _, v := range values {
+			encodings = append(encodings, encodeSortableKey(t, coder, document.NewKeyWith(v)))
+		}
+
+		for i := range len(encodings) - 1 {
+			cmp := bytes.Compare(encodings[i], encodings[i+1])
+			if cmp >= 0 {
+				t.Errorf("Sort order violation: %v (% x) should be < %v (% x), but bytes.Compare = %d",
+					values[i], encodings[i], values[i+1], encodings[i+1], cmp)
+			}
+		}
+
+		for n, v := range values {
+			decKey, err := coder.DecodeKey(encodings[n])
+			if err != nil {
+				t.Fatalf("Decode failed for %v: %v", v, err)
+			}
+			if !typed {
+				if cmp, err := document.NewKeyWith(v).Compare(decKey); err != nil || cmp != 0 {
+					t.Errorf("%v (%T) != %v (%T)", decKey[0], decKey[0], v, v)
+				}
+				continue
+			}
+			switch dv := decKey[0].(type) {
+			case float32:
+				if dv != v {
+					t.Errorf("%v != %v", dv, v)
+				}
+			case float64:
+				// Coders without a float32 representation, such as memcomparable, widen float32 values
+				if dv != float64(v) {
+					t.Errorf("%v != %v", dv, v)
+				}
+			default:
+				t.Errorf("%v (%T) != %v (%T)", decKey[0], decKey[0], v, v)
+			}
+		}
+	})
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package key
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+// IntegerKeyTest tests the ordering, round-trip and size of integers around the byte length boundaries.
+// Encoded sizes must not decrease as the magnitudes increase, so variable-length encodings are verified
+// to be compact while fixed-length encodings pass trivially.
+func IntegerKeyTest(t *testing.T, coder document.KeyCoder) {
+	t.Helper()
+
+	// Values in expected sort order
+	ints := []int64{
+		math.MinInt64,
+		math.MinInt64 + 1,
+		-1 << 56,
+		-1<<56 + 1,
+		-1 << 32,
+		-1 << 16,
+		-1<<8 - 1,
+		-1 << 8,
+		-1<<8 + 1,
+		-2,
+		-1,
+		0,
+		1,
+		2,
+		1<<8 - 1,
+		1 << 8,
+		1<<16 - 1,
+		1 << 16,
+		1 << 32,
+		1<<56 - 1,
+		1 << 56,
+		math.MaxInt64 - 1,
+		math.MaxInt64,
+	}
+
+	uints := []uint64{
+		0,
+		1,
+		1<<8 - 1,
+		1 << 8,
+		1 << 32,
+		math.MaxInt64,
+		math.MaxInt64 + 1,
+		math.MaxUint64 - 1,
+		math.MaxUint64,
+	}
+
+	abs := func(v int64) uint64 {
+		if v < 0 {
+			return uint64(-(v + 1)) + 1
+		}
+		return uint64(v)
+	}
+
+	testCases := []struct {
+		name   string
+		values []any
+		mags   []uint64
+	}{
+		{
+			name: "int",
+		},
+		{
+			name: "uint",
+		},
+	}
+	for _, v := range ints {
+		testCases[0].values = append(testCases[0].values, v)
+		testCases[0].mags = append(testCases[0].mags, abs(v))
+	}
+	for _, v := range uints {
+		testCases[1].values = append(testCases[1].values, v)
+		testCases[1].mags = append(testCases[1].mags, v)
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var encodings [][]byte
+			for _, v := range tc.values {
+				encodings = append(encodings, encodeSortableKey(t, coder, document.NewKeyWith(v, "suffix")))
+			}
+
+			// Ordering
+			for i := range len(encodings) - 1 {
+				cmp := bytes.Compare(encodings[i], encodings[i+1])
+				if cmp >= 0 {
+					t.Errorf("Sort order violation: %v (% x) should be < %v (% x), but bytes.Compare = %d",
+						tc.values[i], encodings[i], tc.values[i+1], encodings[i+1], cmp)
+				}
+			}
+
+			// Round-trip
+			for n, v := range tc.values {
+				key := document.NewKeyWith(v, "suffix")
+				decKey, err := coder.DecodeKey(encodings[n])
+				if err != nil {
+					t.Fatalf("Decode failed for %v: %v", v, err)
+				}
+				if !key.Equal(decKey) {
+					t.Errorf("%v != %v", key, decKey)
+				}
+			}
+
+			// Size
+			for i := range encodings {
+				for j := range encodings {
+					if tc.mags[i] < tc.mags[j] && len(encodings[j]) < len(encodings[i]) {
+						t.Errorf("Size violation: %v (%d bytes) should not be longer than %v (%d bytes)",
+							tc.values[i], len(encodings[i]), tc.values[j], len(encodings[j]))
+					}
+				}
+			}
+		})
+	}
+}
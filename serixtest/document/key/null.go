@@ -0,0 +1,130 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package key
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+// NullOrderKeyTest tests that null elements sort first or last as specified by their null orders,
+// relative to the lowest and highest values of each element type.
+func NullOrderKeyTest(t *testing.T, coder document.KeyCoder) {
+	t.Helper()
+
+	values := []any{
+		false,
+		true,
+		int64(math.MinInt64),
+		int64(math.MaxInt64),
+		uint64(0),
+		uint64(math.MaxUint64),
+		math.Inf(-1),
+		math.Inf(1),
+		"",
+		"\xff",
+		[]byte{},
+		[]byte{0xFF},
+		[16]byte{},
+		[16]byte{0xFF},
+		[8]byte{},
+		time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Duration(math.MinInt64),
+		time.Duration(math.MaxInt64),
+		new(big.Float).SetInf(true),
+		new(big.Int).Lsh(big.NewInt(-1), 100),
+		document.NewKeyWith(),
+		document.NewKeyWith(nil),
+	}
+
+	testCases := []struct {
+		order     document.Order
+		nulls     document.NullOrder
+		nullsLast bool
+	}{
+		{document.Ascending, document.NullsDefault, false},
+		{document.Ascending, document.NullsFirst, false},
+		{document.Ascending, document.NullsLast, true},
+		{document.Descending, document.NullsDefault, true},
+		{document.Descending, document.NullsFirst, false},
+		{document.Descending, document.NullsLast, true},
+	}
+
+	for _, tc := range testCases {
+		null := document.NewOrderedValue(nil, tc.order).WithNulls(tc.nulls)
+		name := strings.TrimSpace(tc.order.String() + " " + tc.nulls.String())
+		t.Run(name, func(t *testing.T) {
+			// The null element decodes back to the same position
+			nullKey := document.NewKeyWith(null, "\xff")
+			nullEncoded := encodeSortableKey(t, coder, nullKey)
+			decKey, err := coder.DecodeKey(nullEncoded)
+			if err != nil {
+				t.Fatalf("Decode failed for %v: %v", nullKey, err)
+			}
+			if !nullKey.Equal(decKey) {
+				t.Errorf("%v != %v", nullKey, decKey)
+			}
+			if isNullsLastKeyElement(decKey[0]) != tc.nullsLast {
+				t.Errorf("%v: null order is not preserved", decKey)
+			}
+
+			// Null elements in nested tuples keep their null orders too
+			nestedKey := document.NewKeyWith("a", document.NewKeyWith(null, int64(1)))
+			decKey, err = coder.DecodeKey(encodeSortableKey(t, coder, nestedKey))
+			if err != nil {
+				t.Fatalf("Decode failed for %v: %v", nestedKey, err)
+			}
+			if !nestedKey.Equal(decKey) {
+				t.Errorf("%v != %v", nestedKey, decKey)
+			}
+			if nested, ok := decKey[1].(document.Key); !ok || isNullsLastKeyElement(nested[0]) != tc.nullsLast {
+				t.Errorf("%v: null order is not preserved", decKey)
+			}
+
+			for _, v := range values {
+				t.Run(fmt.Sprintf("%T", v), func(t *testing.T) {
+					key := document.NewKeyWith(document.NewOrderedValue(v, tc.order).WithNulls(tc.nulls), "")
+					encoded := encodeSortableKey(t, coder, key)
+					want := -1
+					if tc.nullsLast {
+						want = 1
+					}
+					if cmp := bytes.Compare(nullEncoded, encoded); cmp != want {
+						t.Errorf("Sort order violation: %v (% x) vs %v (% x), bytes.Compare = %d, expected %d",
+							nullKey, nullEncoded, key, encoded, cmp, want)
+					}
+					if cmp, err := nullKey.Compare(key); err != nil || cmp != want {
+						t.Errorf("Key order violation: %v vs %v (cmp = %d, err = %v), expected %d", nullKey, key, cmp, err, want)
+					}
+				})
+			}
+		})
+	}
+}
+
+// isNullsLastKeyElement returns true if the specified null key element is sorted after the other values.
+func isNullsLastKeyElement(elem any) bool {
+	if ov, ok := elem.(document.OrderedValue); ok {
+		return ov.IsNullsLast()
+	}
+	return false
+}
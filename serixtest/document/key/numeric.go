@@ -0,0 +1,144 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package key
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"math/rand"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+// RandomSeedEnv is the environment variable which overrides the seed of the random keys, so that a failure can be
+// reproduced with the seed logged by the failed test.
+const RandomSeedEnv = "SERIXTEST_SEED"
+
+// newRandom returns a new random source seeded by RandomSeedEnv or the current time, and logs the seed.
+// nolint: gosec
+func newRandom(t *testing.T) *rand.Rand {
+	t.Helper()
+	seed := time.Now().UnixNano()
+	if env := os.Getenv(RandomSeedEnv); env != "" {
+		v, err := strconv.ParseInt(env, 10, 64)
+		if err != nil {
+			t.Fatalf("%s (%s) is invalid: %v", RandomSeedEnv, env, err)
+		}
+		seed = v
+	}
+	t.Logf("random seed: %s=%d", RandomSeedEnv, seed)
+	return rand.New(rand.NewSource(seed))
+}
+
+// randomNumber returns a random number of a random numeric type.
+func randomNumber(r *rand.Rand) any {
+	// Small magnitudes make equal values across types likely
+	small := r.Int63n(21) - 10
+	switch r.Intn(14) {
+	case 0:
+		return int8(small)
+	case 1:
+		return int16(r.Intn(math.MaxInt16*2+1) - math.MaxInt16)
+	case 2:
+		return int32(small * 1000)
+	case 3:
+		return r.Int63() - r.Int63()
+	case 4:
+		return int(small)
+	case 5:
+		return uint8(r.Intn(11))
+	case 6:
+		return uint32(r.Uint32())
+	case 7:
+		return r.Uint64()
+	case 8:
+		return float32(small) / 4
+	case 9:
+		return float64(small) / 8
+	case 10:
+		return r.NormFloat64() * math.Pow(10, float64(r.Intn(40)-20))
+	case 11:
+		specials := []float64{math.Inf(-1), math.Inf(1), math.MaxFloat64, -math.MaxFloat64, math.SmallestNonzeroFloat64, 0.1}
+		return specials[r.Intn(len(specials))]
+	case 12:
+		v := new(big.Int).Lsh(big.NewInt(small), uint(r.Intn(100)))
+		return v
+	default:
+		return document.NewDecimal(big.NewInt(small*1000+r.Int63n(1000)), int32(r.Intn(6)))
+	}
+}
+
+// NumericOrderKeyTest tests that the encoded byte order of random mixed numeric keys matches document.Key.Compare.
+func NumericOrderKeyTest(t *testing.T, coder document.KeyCoder) {
+	t.Helper()
+
+	const nKeys = 1000
+
+	r := newRandom(t)
+
+	randomKey := func() document.Key {
+		key := document.NewKey()
+		for range r.Intn(3) + 1 {
+			key = append(key, randomNumber(r))
+		}
+		return key
+	}
+
+	sign := func(v int) int {
+		switch {
+		case v < 0:
+			return -1
+		case 0 < v:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	for range nKeys {
+		a := randomKey()
+		b := randomKey()
+		if r.Intn(4) == 0 {
+			// Same value with a different type
+			b = document.NewKeyWith(a...)
+			b[len(b)-1] = randomNumber(r)
+		}
+
+		ea := encodeSortableKey(t, coder, a)
+		eb := encodeSortableKey(t, coder, b)
+
+		cmp, err := a.Compare(b)
+		if err != nil {
+			t.Fatalf("Compare failed for %v and %v: %v", a, b, err)
+		}
+		if bytesCmp := bytes.Compare(ea, eb); sign(bytesCmp) != sign(cmp) {
+			t.Errorf("Sort order mismatch: %v (% x) vs %v (% x): bytes.Compare = %d, Key.Compare = %d",
+				a, ea, b, eb, bytesCmp, cmp)
+		}
+
+		decKey, err := coder.DecodeKey(ea)
+		if err != nil {
+			t.Fatalf("Decode failed for %v: %v", a, err)
+		}
+		if !a.Equal(decKey) {
+			t.Errorf("%v != %v", a, decKey)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package key
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+// PrefixRangeKeyTest tests that the prefix ranges of the given coder contain exactly the keys beginning with the prefixes.
+func PrefixRangeKeyTest(t *testing.T, coder document.KeyCoder) {
+	t.Helper()
+
+	ranger, ok := coder.(document.KeyRanger)
+	if !ok {
+		t.Skipf("%s: prefix range is not supported", coder.Name())
+	}
+
+	testCases := []struct {
+		name    string
+		prefix  document.Key
+		inside  []document.Key
+		outside []document.Key
+	}{
+		{
+			name:   "string",
+			prefix: document.NewKeyWith("users"),
+			inside: []document.Key{
+				document.NewKeyWith("users"),
+				document.NewKeyWith("users", nil),
+				document.NewKeyWith("users", int64(1)),
+				document.NewKeyWith("users", "a", "b"),
+			},
+			outside: []document.Key{
+				document.NewKeyWith(),
+				document.NewKeyWith("user"),
+				document.NewKeyWith("userz"),
+				document.NewKeyWith("users\x00"),
+				document.NewKeyWith("usersX"),
+				document.NewKeyWith("users\xff"),
+				document.NewKeyWith("uset"),
+			},
+		},
+		{
+			name:   "tenant",
+			prefix: document.NewKeyWith("users", int64(42)),
+			inside: []document.Key{
+				document.NewKeyWith("users", int64(42)),
+				document.NewKeyWith("users", int64(42), nil),
+				document.NewKeyWith("users", int64(42), int64(-1)),
+				document.NewKeyWith("users", int64(42), "a"),
+				document.NewKeyWith("users", int64(42), []byte{0xFF}),
+			},
+			outside: []document.Key{
+				document.NewKeyWith("users"),
+				document.NewKeyWith("users", int64(41)),
+				document.NewKeyWith("users", int64(43)),
+				document.NewKeyWith("users", int64(4200)),
+				document.NewKeyWith("usersX", int64(42)),
+				document.NewKeyWith("users\x00", int64(42)),
+			},
+		},
+		{
+			name:   "bytes",
+			prefix: document.NewKeyWith([]byte{0x00, 0xFF}),
+			inside: []document.Key{
+				document.NewKeyWith([]byte{0x00, 0xFF}),
+				document.NewKeyWith([]byte{0x00, 0xFF}, "a"),
+			},
+			outside: []document.Key{
+				document.NewKeyWith([]byte{0x00}),
+				document.NewKeyWith([]byte{0x00, 0xFF, 0x00}),
+				document.NewKeyWith([]byte{0x00, 0xFF, 0xFF}),
+				document.NewKeyWith([]byte{0x01}),
+			},
+		},
+		{
+			name:   "descending",
+			prefix: document.NewKeyWith("users", document.Desc("b")),
+			inside: []document.Key{
+				document.NewKeyWith("users", document.Desc("b")),
+				document.NewKeyWith("users", document.Desc("b"), int64(1)),
+			},
+			outside: []document.Key{
+				document.NewKeyWith("users", document.Desc("a")),
+				document.NewKeyWith("users", document.Desc("b\x00")),
+				document.NewKeyWith("users", document.Desc("ba")),
+				document.NewKeyWith("users", document.Desc("c")),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			encodeSortableKey(t, coder, tc.prefix)
+			begin, end, err := ranger.PrefixRange(tc.prefix)
+			if err != nil {
+				t.Fatal(err)
+			}
+			inRange := func(b []byte) bool {
+				return bytes.Compare(begin, b) <= 0 && (end == nil || bytes.Compare(b, end) < 0)
+			}
+			for _, key := range tc.inside {
+				if b := encodeSortableKey(t, coder, key); !inRange(b) {
+					t.Errorf("%v (% x) should be in [% x, % x)", key, b, begin, end)
+				}
+			}
+			for _, key := range tc.outside {
+				if b := encodeSortableKey(t, coder, key); inRange(b) {
+					t.Errorf("%v (% x) should not be in [% x, % x)", key, b, begin, end)
+				}
+			}
+
+			// The successor range contains only the prefix key itself
+			next := document.KeyNext(begin)
+			for _, key := range tc.inside[1:] {
+				if b := encodeSortableKey(t, coder, key); bytes.Compare(b, next) < 0 {
+					t.Errorf("%v (% x) should not be in [% x, % x)", key, b, begin, next)
+				}
+			}
+		})
+	}
+}
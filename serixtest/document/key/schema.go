@@ -0,0 +1,210 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package key
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+// SchemaKeyTest tests that keys decoded by the given coder are cast back to the types of the index elements,
+// and that keys which disagree with the index elements are rejected.
+func SchemaKeyTest(t *testing.T, coder document.KeyCoder) {
+	t.Helper()
+
+	type indexElement struct {
+		typ   document.ElementType
+		order document.Order
+		value any
+	}
+
+	newIndex := func(elems []indexElement) (document.Index, document.Key) {
+		idx := document.NewIndex().SetName("idx").SetType(document.SecondaryIndex)
+		key := document.NewKey()
+		for n, elem := range elems {
+			name := string(rune('a' + n))
+			idx.AddElement(document.NewElement().SetName(name).SetType(elem.typ))
+			idx.SetElementOrder(name, elem.order)
+			key = append(key, elem.value)
+		}
+		return idx, key
+	}
+
+	testCases := []struct {
+		name  string
+		elems []indexElement
+	}{
+		{
+			name: "primitive",
+			elems: []indexElement{
+				{document.Int8Type, document.Ascending, int8(-8)},
+				{document.Int16Type, document.Ascending, int16(16)},
+				{document.Int32Type, document.Ascending, int32(-32)},
+				{document.Int64Type, document.Ascending, int64(64)},
+				{document.Float32Type, document.Ascending, float32(1.5)},
+				{document.Float64Type, document.Ascending, float64(2)},
+				{document.StringType, document.Ascending, "abc"},
+				{document.BinaryType, document.Ascending, []byte{0x00, 0x01}},
+				{document.BoolType, document.Ascending, true},
+				{document.StringType, document.Ascending, nil},
+			},
+		},
+		{
+			name: "datetime",
+			elems: []indexElement{
+				{document.DatetimeType, document.Ascending, time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)},
+			},
+		},
+		{
+			name: "descending",
+			elems: []indexElement{
+				{document.Int8Type, document.Descending, int8(8)},
+				{document.StringType, document.Ascending, "abc"},
+				{document.Float32Type, document.Descending, float32(-1.5)},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			idx, values := newIndex(tc.elems)
+			key, err := document.NewIndexKeyWith(idx, values...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			encoded := encodeSortableKey(t, coder, key)
+			decKey, err := document.DecodeIndexKey(coder, idx, encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !key.Equal(decKey) {
+				t.Errorf("%v != %v", decKey, key)
+			}
+			for n, elem := range decKey {
+				if ov, ok := elem.(document.OrderedValue); ok {
+					elem = ov.Value
+				}
+				if reflect.TypeOf(elem) != reflect.TypeOf(values[n]) {
+					t.Errorf("%v (%T) != %v (%T)", elem, elem, values[n], values[n])
+				}
+			}
+
+			// Prefix keys are allowed
+			decKey, err = document.DecodeIndexKey(coder, idx, encodeSortableKey(t, coder, key[:1]))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !key[:1].Equal(decKey) {
+				t.Errorf("%v != %v", decKey, key[:1])
+			}
+		})
+	}
+
+	t.Run("collation", func(t *testing.T) {
+		CollationKeyTest(t, coder)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		idx, _ := newIndex([]indexElement{
+			{document.Int8Type, document.Ascending, nil},
+			{document.StringType, document.Descending, nil},
+		})
+		keys := []document.Key{
+			document.NewKeyWith("abc"),
+			document.NewKeyWith(int64(1000)),
+			document.NewKeyWith(int64(1), "abc"),
+			document.NewKeyWith(int64(1), document.Desc("abc"), int64(1)),
+		}
+		for _, key := range keys {
+			_, err := document.DecodeIndexKey(coder, idx, encodeSortableKey(t, coder, key))
+			if !errors.Is(err, document.ErrInvalid) {
+				t.Errorf("%v should be invalid for %s: %v", key, idx.Name(), err)
+			}
+		}
+	})
+}
+
+// CollationKeyTest tests that string index elements with collations are encoded as their collation keys,
+// so that strings equal by the collation have the same encoding and the keys sort by the collation.
+func CollationKeyTest(t *testing.T, coder document.KeyCoder) {
+	t.Helper()
+
+	testCases := []struct {
+		collation document.Collation
+		equals    []string
+		values    []string
+	}{
+		{
+			collation: document.CaseFoldedCollation,
+			equals:    []string{"apple", "Apple", "APPLE"},
+			values:    []string{"apple", "Banana", "cherry", "Straße", "STRASSF"},
+		},
+		{
+			collation: document.NFCCollation,
+			equals:    []string{"caf\u00e9", "cafe\u0301"},
+			values:    []string{"Cafe", "cafe", "cafez", "caf\u00e9"},
+		},
+		{
+			collation: document.SimpleCollation,
+			equals:    []string{"resume", "Résumé", "RESUME"},
+			values:    []string{"Apple", "banana", "Écrit", "resume", "Résumés", "zebra"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.collation.String(), func(t *testing.T) {
+			idx := document.NewIndex().SetName("idx").SetType(document.SecondaryIndex)
+			idx.AddElement(document.NewElement().SetName("a").SetType(document.StringType))
+			idx.SetElementCollation("a", tc.collation)
+
+			encode := func(v string) []byte {
+				key, err := document.NewIndexKeyWith(idx, v)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return encodeSortableKey(t, coder, key)
+			}
+
+			encoded := encode(tc.equals[0])
+			for _, v := range tc.equals[1:] {
+				if other := encode(v); !bytes.Equal(encoded, other) {
+					t.Errorf("%q (% x) != %q (% x)", tc.equals[0], encoded, v, other)
+				}
+			}
+
+			for i := range len(tc.values) - 1 {
+				e1 := encode(tc.values[i])
+				e2 := encode(tc.values[i+1])
+				if bytes.Compare(e1, e2) >= 0 {
+					t.Errorf("Sort order violation: %q (% x) should be < %q (% x)", tc.values[i], e1, tc.values[i+1], e2)
+				}
+			}
+
+			// Collation keys decode back as strings
+			decKey, err := document.DecodeIndexKey(coder, idx, encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s, ok := decKey[0].(string); !ok || s != tc.collation.Key(tc.equals[0]) {
+				t.Errorf("%v (%T) != %q", decKey[0], decKey[0], tc.collation.Key(tc.equals[0]))
+			}
+		})
+	}
+}
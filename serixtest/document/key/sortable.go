@@ -16,8 +16,11 @@ package key
 
 import (
 	"bytes"
+	"errors"
 	"math"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/cybergarage/go-serix/serix/document"
 )
@@ -25,6 +28,27 @@ import (
 // SortableKeyTest tests that the given coder produces sortable encodings for various key types.
 func SortableKeyTest(t *testing.T, coder document.KeyCoder) {
 	t.Helper()
+	sortableKeyTest(t, coder, true, NestedKeyTest)
+}
+
+// IntegerLastSortableKeyTest runs the same tests as SortableKeyTest for the given coder which encodes integers with
+// markers sorting after strings, byte strings and nested tuples, as the FoundationDB tuple layer does.
+func IntegerLastSortableKeyTest(t *testing.T, coder document.KeyCoder) {
+	t.Helper()
+	sortableKeyTest(t, coder, true, IntegerLastNestedKeyTest)
+}
+
+// NumericSortableKeyTest runs the same tests as SortableKeyTest for the given coder which encodes all numeric types
+// with one ordered representation, where the numbers decode back with their values but not always with their types.
+func NumericSortableKeyTest(t *testing.T, coder document.KeyCoder) {
+	t.Helper()
+	sortableKeyTest(t, coder, false, NestedKeyTest)
+}
+
+// sortableKeyTest runs the sortable tests with the specified nested key test, and checks the numeric types
+// of the decoded keys only if typed is true.
+func sortableKeyTest(t *testing.T, coder document.KeyCoder, typed bool, nestedKeyTest func(*testing.T, document.KeyCoder)) {
+	t.Helper()
 
 	t.Run("int", func(t *testing.T) {
 		// Values in expected sort order
@@ -68,7 +92,7 @@ func SortableKeyTest(t *testing.T, coder document.KeyCoder) {
 			-1000.5,
 			-1.0,
 			-0.5,
-			math.Copysign(0, -1), // Note: -0.0 and 0.0 encode the same
+			math.Copysign(0, -1), // Note: -0.0 sorts before or equal to 0.0 by the zero policy of the coder
 			0.0,
 			0.5,
 			1.0,
@@ -183,4 +207,555 @@ func SortableKeyTest(t *testing.T, coder document.KeyCoder) {
 			})
 		}
 	})
+
+	t.Run("bytes", func(t *testing.T) {
+		// Values in expected sort order
+		values := [][]byte{
+			{},
+			{0x00},
+			{0x00, 0x00},
+			{0x00, 0xFF},
+			[]byte("a"),
+			[]byte("a\x00"),
+			[]byte("a\x00b"),
+			[]byte("aa"),
+			[]byte("aaa"),
+			[]byte("ab"),
+			[]byte("b"),
+			[]byte("zz"),
+			{0xFF},
+			{0xFF, 0x00},
+		}
+
+		// Encode all values
+		var encodings [][]byte
+		for _, v := range values {
+			encodings = append(encodings, encodeSortableKey(t, coder, document.NewKeyWith(v)))
+		}
+
+		// Verify each pair is in correct order
+		for i := range len(encodings) - 1 {
+			cmp := bytes.Compare(encodings[i], encodings[i+1])
+			if cmp >= 0 {
+				t.Errorf("Sort order violation: % x (% x) should be < % x (% x), but bytes.Compare = %d",
+					values[i], encodings[i], values[i+1], encodings[i+1], cmp)
+			}
+		}
+	})
+
+	t.Run("float", func(t *testing.T) {
+		floatKeyTest(t, coder, typed)
+	})
+
+	t.Run("time", func(t *testing.T) {
+		TimeKeyTest(t, coder)
+	})
+
+	t.Run("bignum", func(t *testing.T) {
+		bigNumberKeyTest(t, coder, typed)
+	})
+
+	t.Run("identifier", func(t *testing.T) {
+		IdentifierKeyTest(t, coder)
+	})
+
+	t.Run("descending", func(t *testing.T) {
+		DescendingKeyTest(t, coder)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		nestedKeyTest(t, coder)
+	})
+
+	t.Run("nulls", func(t *testing.T) {
+		NullOrderKeyTest(t, coder)
+	})
+}
+
+// encodeSortableKey encodes the specified key, skipping the test if the coder does not support the key.
+func encodeSortableKey(t *testing.T, coder document.KeyCoder, key document.Key) []byte {
+	t.Helper()
+	encoded, err := coder.EncodeKey(key)
+	if err != nil {
+		if errors.Is(err, document.ErrNotSupported) {
+			t.Skipf("%s: %v", coder.Name(), err)
+		}
+		t.Fatalf("Encode failed for %v: %v", key, err)
+	}
+	return encoded
+}
+
+// DescendingKeyTest tests that descending key elements reverse the sort order of the given coder.
+func DescendingKeyTest(t *testing.T, coder document.KeyCoder) {
+	t.Helper()
+
+	testCases := []struct {
+		name   string
+		values []any
+	}{
+		{
+			name:   "int",
+			values: []any{int64(math.MinInt64), int64(-1000), int64(-1), int64(0), int64(1), int64(1000), int64(math.MaxInt64)},
+		},
+		{
+			name:   "uint",
+			values: []any{uint64(0), uint64(1), uint64(1000), uint64(math.MaxUint64)},
+		},
+		{
+			name:   "float",
+			values: []any{math.Inf(-1), -1000.5, -0.5, 0.0, 0.5, 1000.5, math.Inf(1)},
+		},
+		{
+			name:   "string",
+			values: []any{"", "\x00", "a", "a\x00", "aa", "ab", "b"},
+		},
+		{
+			name:   "bytes",
+			values: []any{[]byte{}, []byte{0x00}, []byte("a"), []byte("ab"), []byte("b")},
+		},
+		{
+			name:   "bool",
+			values: []any{true, false},
+		},
+		{
+			name:   "null",
+			values: []any{nil, int64(0), "a"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for i := range tc.values {
+				for j := range tc.values {
+					if i == j {
+						continue
+					}
+					a := tc.values[i]
+					b := tc.values[j]
+					ascCmp := bytes.Compare(
+						encodeSortableKey(t, coder, document.NewKeyWith(a)),
+						encodeSortableKey(t, coder, document.NewKeyWith(b)))
+					descCmp := bytes.Compare(
+						encodeSortableKey(t, coder, document.NewKeyWith(document.Desc(a))),
+						encodeSortableKey(t, coder, document.NewKeyWith(document.Desc(b))))
+					if ascCmp == 0 || descCmp != -ascCmp {
+						t.Errorf("Sort order not reversed: %v vs %v (asc = %d, desc = %d)", a, b, ascCmp, descCmp)
+					}
+				}
+			}
+
+			// Descending elements must decode back to the original values
+			for _, v := range tc.values {
+				key := document.NewKeyWith(document.Desc(v))
+				decKey, err := coder.DecodeKey(encodeSortableKey(t, coder, key))
+				if err != nil {
+					t.Fatalf("Decode failed for %v: %v", key, err)
+				}
+				if !key.Equal(decKey) {
+					t.Errorf("%s != %s", key, decKey)
+				}
+			}
+		})
+	}
+
+	t.Run("mixed", func(t *testing.T) {
+		// Keys in expected sort order: ascending int, descending string, ascending string
+		keys := []document.Key{
+			document.NewKeyWith(int64(1), document.Desc("b"), "a"),
+			document.NewKeyWith(int64(1), document.Desc("b"), "b"),
+			document.NewKeyWith(int64(1), document.Desc("ab"), "a"),
+			document.NewKeyWith(int64(1), document.Desc("a"), "a"),
+			document.NewKeyWith(int64(1), document.Desc(""), "a"),
+			document.NewKeyWith(int64(2), document.Desc("b"), "a"),
+			document.NewKeyWith(int64(2), document.Desc("a"), "a"),
+		}
+
+		var encodings [][]byte
+		for _, key := range keys {
+			encodings = append(encodings, encodeSortableKey(t, coder, key))
+		}
+
+		for i := range len(encodings) - 1 {
+			cmp := bytes.Compare(encodings[i], encodings[i+1])
+			if cmp >= 0 {
+				t.Errorf("Sort order violation: %v (% x) should be < %v (% x), but bytes.Compare = %d",
+					keys[i], encodings[i], keys[i+1], encodings[i+1], cmp)
+			}
+			if cmp, err := keys[i].Compare(keys[i+1]); err != nil || cmp >= 0 {
+				t.Errorf("Key order violation: %v should be < %v (cmp = %d, err = %v)", keys[i], keys[i+1], cmp, err)
+			}
+		}
+
+		for n, key := range keys {
+			decKey, err := coder.DecodeKey(encodings[n])
+			if err != nil {
+				t.Fatalf("Decode failed for %v: %v", key, err)
+			}
+			if !key.Equal(decKey) {
+				t.Errorf("%s != %s", key, decKey)
+			}
+		}
+	})
+}
+
+// NestedKeyTest tests that nested tuples round-trip and sort lexicographically by their elements.
+func NestedKeyTest(t *testing.T, coder document.KeyCoder) {
+	t.Helper()
+
+	// Keys in expected sort order
+	keys := []document.Key{
+		document.NewKeyWith("tenant", document.NewKeyWith(), int64(9)),
+		document.NewKeyWith("tenant", document.NewKeyWith(nil), int64(9)),
+		document.NewKeyWith("tenant", document.NewKeyWith(nil, int64(1)), int64(9)),
+		document.NewKeyWith("tenant", document.NewKeyWith(int64(2024)), int64(9)),
+		document.NewKeyWith("tenant", document.NewKeyWith(int64(2024), nil), int64(9)),
+		document.NewKeyWith("tenant", document.NewKeyWith(int64(2024), int64(1)), int64(1)),
+		document.NewKeyWith("tenant", document.NewKeyWith(int64(2024), int64(1)), int64(2)),
+		document.NewKeyWith("tenant", document.NewKeyWith(int64(2024), int64(12)), int64(1)),
+		document.NewKeyWith("tenant", document.NewKeyWith(int64(2025), int64(1)), int64(1)),
+		document.NewKeyWith("tenant", document.NewKeyWith("\x00", document.NewKeyWith("a")), int64(1)),
+		document.NewKeyWith("tenant", document.NewKeyWith("\x00", document.NewKeyWith("a", nil)), int64(1)),
+		document.NewKeyWith("tenant", document.NewKeyWith("a"), int64(1)),
+		document.NewKeyWith("tenant", []any{"a", "b"}, int64(1)),
+	}
+
+	sortedNestedKeyTest(t, coder, keys)
+}
+
+// IntegerLastNestedKeyTest tests the same nested tuples as NestedKeyTest for the given coder which encodes integers
+// with markers sorting after strings, byte strings and nested tuples.
+func IntegerLastNestedKeyTest(t *testing.T, coder document.KeyCoder) {
+	t.Helper()
+
+	// Keys in expected sort order
+	keys := []document.Key{
+		document.NewKeyWith("tenant", document.NewKeyWith(), int64(9)),
+		document.NewKeyWith("tenant", document.NewKeyWith(nil), int64(9)),
+		document.NewKeyWith("tenant", document.NewKeyWith(nil, int64(1)), int64(9)),
+		document.NewKeyWith("tenant", document.NewKeyWith("\x00", document.NewKeyWith("a")), int64(1)),
+		document.NewKeyWith("tenant", document.NewKeyWith("\x00", document.NewKeyWith("a", nil)), int64(1)),
+		document.NewKeyWith("tenant", document.NewKeyWith("a"), int64(1)),
+		document.NewKeyWith("tenant", []any{"a", "b"}, int64(1)),
+		document.NewKeyWith("tenant", document.NewKeyWith(int64(2024)), int64(9)),
+		document.NewKeyWith("tenant", document.NewKeyWith(int64(2024), nil), int64(9)),
+		document.NewKeyWith("tenant", document.NewKeyWith(int64(2024), int64(1)), int64(1)),
+		document.NewKeyWith("tenant", document.NewKeyWith(int64(2024), int64(1)), int64(2)),
+		document.NewKeyWith("tenant", document.NewKeyWith(int64(2024), int64(12)), int64(1)),
+		document.NewKeyWith("tenant", document.NewKeyWith(int64(2025), int64(1)), int64(1)),
+	}
+
+	sortedNestedKeyTest(t, coder, keys)
+}
+
+// sortedNestedKeyTest tests that the specified keys in the expected sort order round-trip and sort in the order.
+func sortedNestedKeyTest(t *testing.T, coder document.KeyCoder, keys []document.Key) {
+	t.Helper()
+
+	var encodings [][]byte
+	for _, key := range keys {
+		encodings = append(encodings, encodeSortableKey(t, coder, key))
+	}
+
+	for i := range len(encodings) - 1 {
+		cmp := bytes.Compare(encodings[i], encodings[i+1])
+		if cmp >= 0 {
+			t.Errorf("Sort order violation: %v (% x) should be < %v (% x), but bytes.Compare = %d",
+				keys[i], encodings[i], keys[i+1], encodings[i+1], cmp)
+		}
+	}
+
+	for n, key := range keys {
+		decKey, err := coder.DecodeKey(encodings[n])
+		if err != nil {
+			t.Fatalf("Decode failed for %v: %v", key, err)
+		}
+		if !key.Equal(decKey) {
+			t.Errorf("%v != %v", key, decKey)
+		}
+	}
+}
+
+// TimeKeyTest tests that timestamps and durations round-trip with their types and sort chronologically.
+func TimeKeyTest(t *testing.T, coder document.KeyCoder) {
+	t.Helper()
+
+	jst := time.FixedZone("JST", 9*60*60)
+
+	testCases := []struct {
+		name   string
+		values []any
+	}{
+		{
+			name: "timestamp",
+			values: []any{
+				time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(1969, 12, 31, 23, 59, 59, 999999999, time.UTC),
+				time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(1970, 1, 1, 0, 0, 0, 1, time.UTC),
+				time.Date(2025, 1, 1, 8, 59, 59, 0, jst),
+				time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, 1, 1, 0, 0, 0, 1, time.UTC),
+				time.Date(2262, 4, 12, 0, 0, 0, 0, time.UTC),
+				time.Date(9999, 12, 31, 23, 59, 59, 999999999, time.UTC),
+			},
+		},
+		{
+			name: "duration",
+			values: []any{
+				time.Duration(math.MinInt64),
+				-time.Hour,
+				-time.Nanosecond,
+				time.Duration(0),
+				time.Nanosecond,
+				time.Second,
+				time.Hour,
+				time.Duration(math.MaxInt64),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var encodings [][]byte
+			for _, v := range tc.values {
+				encodings = append(encodings, encodeSortableKey(t, coder, document.NewKeyWith(v)))
+			}
+
+			for i := range len(encodings) - 1 {
+				cmp := bytes.Compare(encodings[i], encodings[i+1])
+				if cmp >= 0 {
+					t.Errorf("Sort order violation: %v (% x) should be < %v (% x), but bytes.Compare = %d",
+						tc.values[i], encodings[i], tc.values[i+1], encodings[i+1], cmp)
+				}
+			}
+
+			for n, v := range tc.values {
+				decKey, err := coder.DecodeKey(encodings[n])
+				if err != nil {
+					t.Fatalf("Decode failed for %v: %v", v, err)
+				}
+				if len(decKey) != 1 {
+					t.Fatalf("%v: unexpected key length %d", decKey, len(decKey))
+				}
+				switch v := v.(type) {
+				case time.Time:
+					decTime, ok := decKey[0].(time.Time)
+					if !ok || !decTime.Equal(v) || decTime.Location() != time.UTC {
+						t.Errorf("%v (%T) != %v", decKey[0], decKey[0], v.UTC())
+					}
+				case time.Duration:
+					decDuration, ok := decKey[0].(time.Duration)
+					if !ok || decDuration != v {
+						t.Errorf("%v (%T) != %v", decKey[0], decKey[0], v)
+					}
+				}
+			}
+		})
+	}
+}
+
+// IdentifierKeyTest tests that fixed-length identifiers such as UUIDs round-trip with their types and sort bytewise.
+func IdentifierKeyTest(t *testing.T, coder document.KeyCoder) {
+	t.Helper()
+
+	// Time-ordered UUIDv7-like identifiers in expected sort order
+	uuids := [][16]byte{
+		{},
+		{0x01, 0x8f, 0x00, 0x00, 0x00, 0x00, 0x70, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		{0x01, 0x8f, 0x00, 0x00, 0x00, 0x00, 0x70, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01},
+		{0x01, 0x8f, 0x00, 0x00, 0x00, 0x01, 0x70, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		{0x01, 0x90, 0x00, 0x00, 0x00, 0x00, 0x70, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+	}
+
+	var encodings [][]byte
+	for _, v := range uuids {
+		key := document.NewKeyWith(v, "suffix")
+		encodings = append(encodings, encodeSortableKey(t, coder, key))
+	}
+
+	for i := range len(encodings) - 1 {
+		cmp := bytes.Compare(encodings[i], encodings[i+1])
+		if cmp >= 0 {
+			t.Errorf("Sort order violation: % x (% x) should be < % x (% x), but bytes.Compare = %d",
+				uuids[i], encodings[i], uuids[i+1], encodings[i+1], cmp)
+		}
+	}
+
+	for n, v := range uuids {
+		decKey, err := coder.DecodeKey(encodings[n])
+		if err != nil {
+			t.Fatalf("Decode failed for % x: %v", v, err)
+		}
+		if decUUID, ok := decKey[0].([16]byte); !ok || decUUID != v {
+			t.Errorf("%v (%T) != % x", decKey[0], decKey[0], v)
+		}
+	}
+
+	// Other fixed-length identifiers decode back as arrays of the same width
+	ids := []any{
+		[8]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+		[12]byte{0x65, 0x4f, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01},
+		[20]byte{0x00, 0xff},
+	}
+	for _, v := range ids {
+		key := document.NewKeyWith(v)
+		decKey, err := coder.DecodeKey(encodeSortableKey(t, coder, key))
+		if err != nil {
+			t.Fatalf("Decode failed for %v: %v", v, err)
+		}
+		if decKey[0] != v {
+			t.Errorf("%v (%T) != %v (%T)", decKey[0], decKey[0], v, v)
+		}
+	}
+}
+
+// BigNumberKeyTest tests that arbitrary-precision integers and decimals round-trip with their types and sort numerically.
+func BigNumberKeyTest(t *testing.T, coder document.KeyCoder) {
+	t.Helper()
+	bigNumberKeyTest(t, coder, true)
+}
+
+func bigNumberKeyTest(t *testing.T, coder document.KeyCoder, typed bool) {
+	t.Helper()
+
+	bigInt := func(s string) *big.Int {
+		v, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			t.Fatalf("invalid big integer: %s", s)
+		}
+		return v
+	}
+
+	bigFloat := func(s string) *big.Float {
+		v, ok := new(big.Float).SetPrec(128).SetString(s)
+		if !ok {
+			t.Fatalf("invalid big float: %s", s)
+		}
+		return v
+	}
+
+	decimal := func(s string) document.Decimal {
+		v, err := document.NewDecimalFromString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	testCases := []struct {
+		name   string
+		values []any
+	}{
+		{
+			name: "bigint",
+			values: []any{
+				bigInt("-100000000000000000000000000000000000000"),
+				bigInt("-18446744073709551616"),
+				bigInt("-1000"),
+				bigInt("-999"),
+				bigInt("-10"),
+				bigInt("-9"),
+				bigInt("-1"),
+				bigInt("0"),
+				bigInt("1"),
+				bigInt("9"),
+				bigInt("10"),
+				bigInt("11"),
+				bigInt("100"),
+				bigInt("18446744073709551616"),
+				bigInt("100000000000000000000000000000000000000"),
+			},
+		},
+		{
+			name: "bigfloat",
+			values: []any{
+				new(big.Float).SetInf(true),
+				bigFloat("-1e100"),
+				bigFloat("-1.5"),
+				bigFloat("-1.25"),
+				bigFloat("-0.001"),
+				bigFloat("0"),
+				bigFloat("1e-100"),
+				bigFloat("0.1"),
+				bigFloat("1"),
+				bigFloat("1.000000000000000000000000001"),
+				bigFloat("12345.6789"),
+				bigFloat("1e100"),
+				new(big.Float).SetInf(false),
+			},
+		},
+		{
+			name: "decimal",
+			values: []any{
+				decimal("-1000.01"),
+				decimal("-1000"),
+				decimal("-99.99"),
+				decimal("-0.10"),
+				decimal("-0.011"),
+				decimal("-0.01"),
+				decimal("0.00"),
+				decimal("0.01"),
+				decimal("0.011"),
+				decimal("0.1"),
+				decimal("19.99"),
+				decimal("20"),
+				decimal("20.01"),
+				decimal("1e30"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var encodings [][]byte
+			for _, v := range tc.values {
+				encodings = append(encodings, encodeSortableKey(t, coder, document.NewKeyWith(v, "suffix")))
+			}
+
+			for i := range len(encodings) - 1 {
+				cmp := bytes.Compare(encodings[i], encodings[i+1])
+				if cmp >= 0 {
+					t.Errorf("Sort order violation: %v (% x) should be < %v (% x), but bytes.Compare = %d",
+						tc.values[i], encodings[i], tc.values[i+1], encodings[i+1], cmp)
+				}
+			}
+
+			for n, v := range tc.values {
+				key := document.NewKeyWith(v, "suffix")
+				decKey, err := coder.DecodeKey(encodings[n])
+				if err != nil {
+					t.Fatalf("Decode failed for %v: %v", v, err)
+				}
+				if !key.Equal(decKey) {
+					t.Errorf("%v != %v", key, decKey)
+				}
+				if len(decKey) != 2 {
+					t.Fatalf("%v: unexpected key length %d", decKey, len(decKey))
+				}
+				if !typed {
+					continue
+				}
+				var ok bool
+				switch v := v.(type) {
+				case *big.Int:
+					switch decKey[0].(type) {
+					case *big.Int:
+						ok = true
+					case int64, uint64:
+						// Integers within 64 bits may decode as built-in types like the FoundationDB tuple layer
+						ok = v.IsInt64() || v.IsUint64()
+					}
+				case *big.Float:
+					_, ok = decKey[0].(*big.Float)
+				case document.Decimal:
+					_, ok = decKey[0].(document.Decimal)
+				}
+				if !ok {
+					t.Errorf("%v (%T) != %v (%T)", decKey[0], decKey[0], v, v)
+				}
+			}
+		})
+	}
 }
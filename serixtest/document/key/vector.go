@@ -0,0 +1,108 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package key
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/cybergarage/go-serix/serix/document"
+)
+
+// KeyVector represents a known-answer vector of a key and its expected encoding.
+type KeyVector struct {
+	Key     document.Key
+	Encoded []byte
+}
+
+// KnownAnswerKeyTest tests that the given coder encodes the keys of the vectors to the expected bytes
+// and decodes the expected bytes back to the equal keys.
+func KnownAnswerKeyTest(t *testing.T, coder document.KeyCoder, vectors []KeyVector) {
+	t.Helper()
+
+	for _, v := range vectors {
+		encoded, err := coder.EncodeKey(v.Key)
+		if err != nil {
+			t.Errorf("Encode failed for %v: %v", v.Key, err)
+			continue
+		}
+		if !bytes.Equal(encoded, v.Encoded) {
+			t.Errorf("%v: % x != % x", v.Key, encoded, v.Encoded)
+		}
+		decKey, err := coder.DecodeKey(v.Encoded)
+		if err != nil {
+			t.Errorf("Decode failed for % x: %v", v.Encoded, err)
+			continue
+		}
+		if !v.Key.Equal(decKey) {
+			t.Errorf("% x: %v != %v", v.Encoded, decKey, v.Key)
+		}
+	}
+}
+
+// FDBTupleKeyVectors returns the known-answer vectors of the FoundationDB tuple layer encoding,
+// taken from the specification and the reference bindings.
+func FDBTupleKeyVectors() []KeyVector {
+	bigInt := func(s string) *big.Int {
+		v, _ := new(big.Int).SetString(s, 10)
+		return v
+	}
+	return []KeyVector{
+		{document.NewKeyWith(), []byte{}},
+		{document.NewKeyWith(nil), []byte{0x00}},
+		{document.NewKeyWith(false), []byte{0x26}},
+		{document.NewKeyWith(true), []byte{0x27}},
+		{document.NewKeyWith([]byte("foo\x00bar")), []byte{0x01, 'f', 'o', 'o', 0x00, 0xFF, 'b', 'a', 'r', 0x00}},
+		{document.NewKeyWith([]byte{}), []byte{0x01, 0x00}},
+		{document.NewKeyWith("FÔO\x00bar"), []byte{0x02, 'F', 0xC3, 0x94, 'O', 0x00, 0xFF, 'b', 'a', 'r', 0x00}},
+		{document.NewKeyWith(""), []byte{0x02, 0x00}},
+		{
+			document.NewKeyWith(document.NewKeyWith([]byte("foo\x00bar"), nil, document.NewKeyWith())),
+			[]byte{0x05, 0x01, 'f', 'o', 'o', 0x00, 0xFF, 'b', 'a', 'r', 0x00, 0x00, 0xFF, 0x05, 0x00, 0x00},
+		},
+		{document.NewKeyWith(int64(0)), []byte{0x14}},
+		{document.NewKeyWith(int64(1)), []byte{0x15, 0x01}},
+		{document.NewKeyWith(int64(-1)), []byte{0x13, 0xFE}},
+		{document.NewKeyWith(int64(255)), []byte{0x15, 0xFF}},
+		{document.NewKeyWith(int64(-255)), []byte{0x13, 0x00}},
+		{document.NewKeyWith(int64(256)), []byte{0x16, 0x01, 0x00}},
+		{document.NewKeyWith(int64(-256)), []byte{0x12, 0xFE, 0xFF}},
+		{document.NewKeyWith(int64(-5551212)), []byte{0x11, 0xAB, 0x4B, 0x93}},
+		{document.NewKeyWith(int64(math.MaxInt64)), []byte{0x1C, 0x7F, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}},
+		{document.NewKeyWith(int64(math.MinInt64)), []byte{0x0C, 0x7F, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}},
+		{document.NewKeyWith(uint64(math.MaxUint64)), []byte{0x1C, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}},
+		{document.NewKeyWith(bigInt("-18446744073709551615")), []byte{0x0C, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}},
+		{
+			document.NewKeyWith(bigInt("18446744073709551616")),
+			[]byte{0x1D, 0x09, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			document.NewKeyWith(bigInt("-18446744073709551616")),
+			[]byte{0x0B, 0xF6, 0xFE, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF},
+		},
+		{document.NewKeyWith(float32(3.14)), []byte{0x20, 0xC0, 0x48, 0xF5, 0xC3}},
+		{document.NewKeyWith(float32(-3.14)), []byte{0x20, 0x3F, 0xB7, 0x0A, 0x3C}},
+		{document.NewKeyWith(-42.0), []byte{0x21, 0x3F, 0xBA, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}},
+		{document.NewKeyWith(0.0), []byte{0x21, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}},
+		{document.NewKeyWith(math.Inf(1)), []byte{0x21, 0xFF, 0xF0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}},
+		{
+			document.NewKeyWith([16]byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xAB, 0xCD, 0xEF, 0xFE, 0xDC, 0xBA, 0x98, 0x76, 0x54, 0x32, 0x10}),
+			[]byte{0x30, 0x01, 0x23, 0x45, 0x67, 0x89, 0xAB, 0xCD, 0xEF, 0xFE, 0xDC, 0xBA, 0x98, 0x76, 0x54, 0x32, 0x10},
+		},
+		{document.NewKeyWith("users", int64(42)), []byte{0x02, 'u', 's', 'e', 'r', 's', 0x00, 0x15, 0x2A}},
+	}
+}
@@ -0,0 +1,355 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/cybergarage/go-serix/serix/document"
+	"github.com/cybergarage/go-serix/serix/document/kv"
+)
+
+// The store tests write the keys beginning with the test names, so that they can share a store
+// which already has other keys.
+
+func testPrefix(t *testing.T) []byte {
+	t.Helper()
+	return append([]byte(t.Name()), 0x00)
+}
+
+func testKey(prefix []byte, suffix []byte) []byte {
+	return append(slices.Clone(prefix), suffix...)
+}
+
+func transact(t *testing.T, store kv.Store, write bool) kv.Transaction {
+	t.Helper()
+	tx, err := store.Transact(write)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+func commit(t *testing.T, tx kv.Transaction) {
+	t.Helper()
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func rangeKeys(t *testing.T, r kv.Reader, begin []byte, end []byte, order document.Order) [][]byte {
+	t.Helper()
+	it, err := r.Range(begin, end, order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := [][]byte{}
+	for it.Next() {
+		keys = append(keys, bytes.Clone(it.Key()))
+	}
+	return keys
+}
+
+// assertValue asserts that the reader has the specified value of the key, or does not have the key if the value is nil.
+func assertValue(t *testing.T, r kv.Reader, key []byte, value []byte) {
+	t.Helper()
+	v, err := r.Get(key)
+	if value == nil {
+		if !errors.Is(err, document.ErrNotExist) {
+			t.Errorf("% x: % x (%v) != not exist", key, v, err)
+		}
+		return
+	}
+	if err != nil {
+		t.Errorf("% x: %v", key, err)
+		return
+	}
+	if !bytes.Equal(v, value) {
+		t.Errorf("% x: % x != % x", key, v, value)
+	}
+}
+
+// TransactionReadWriteTest tests that the transactions get, set and delete the items, and read their own writes.
+func TransactionReadWriteTest(t *testing.T, store kv.Store) {
+	t.Helper()
+	prefix := testPrefix(t)
+	k1 := testKey(prefix, []byte("k1"))
+	k2 := testKey(prefix, []byte("k2"))
+
+	tx := transact(t, store, true)
+	if tx.IsReadOnly() {
+		t.Errorf("read-write transaction is read-only")
+	}
+	assertValue(t, tx, k1, nil)
+	if err := tx.Set(k1, []byte("v1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Set(k2, []byte("v2")); err != nil {
+		t.Fatal(err)
+	}
+	assertValue(t, tx, k1, []byte("v1"))
+	if err := tx.Set(k1, []byte("v1'")); err != nil {
+		t.Fatal(err)
+	}
+	assertValue(t, tx, k1, []byte("v1'"))
+	commit(t, tx)
+
+	tx = transact(t, store, true)
+	assertValue(t, tx, k1, []byte("v1'"))
+	assertValue(t, tx, k2, []byte("v2"))
+	if err := tx.Delete(k1); err != nil {
+		t.Fatal(err)
+	}
+	assertValue(t, tx, k1, nil)
+	if err := tx.Delete(k1); !errors.Is(err, document.ErrNotExist) {
+		t.Errorf("%v", err)
+	}
+	commit(t, tx)
+
+	tx = transact(t, store, false)
+	assertValue(t, tx, k1, nil)
+	assertValue(t, tx, k2, []byte("v2"))
+	commit(t, tx)
+
+	// Empty values are distinct from missing keys
+	tx = transact(t, store, true)
+	if err := tx.Set(k1, []byte{}); err != nil {
+		t.Fatal(err)
+	}
+	commit(t, tx)
+	tx = transact(t, store, false)
+	assertValue(t, tx, k1, []byte{})
+	commit(t, tx)
+}
+
+// TransactionOrderTest tests that the range iterators return the items in the byte order of their keys,
+// including the uncommitted writes of the transactions.
+func TransactionOrderTest(t *testing.T, store kv.Store) {
+	t.Helper()
+	prefix := testPrefix(t)
+
+	suffixes := [][]byte{
+		{},
+		{0x00},
+		{0x00, 0x00},
+		{0x00, 0x01},
+		{0x01},
+		{0x7F},
+		{0x80},
+		{0xFE, 0xFF},
+		{0xFF},
+		{0xFF, 0x00},
+		{0xFF, 0xFF},
+		[]byte("a"),
+		[]byte("a\x00"),
+		[]byte("ab"),
+		[]byte("b"),
+	}
+	keys := [][]byte{}
+	for _, suffix := range suffixes {
+		keys = append(keys, testKey(prefix, suffix))
+	}
+	slices.SortFunc(keys, bytes.Compare)
+
+	// Writes in a shuffled order
+	tx := transact(t, store, true)
+	for n := range keys {
+		key := keys[(n*7)%len(keys)]
+		if err := tx.Set(key, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	commit(t, tx)
+
+	begin := prefix
+	end := document.KeyStrinc(prefix)
+
+	assertRange := func(t *testing.T, r kv.Reader, begin []byte, end []byte, expected [][]byte) {
+		t.Helper()
+		rkeys := rangeKeys(t, r, begin, end, document.Ascending)
+		if !slices.EqualFunc(rkeys, expected, bytes.Equal) {
+			t.Errorf("ascending [% x, % x): % x != % x", begin, end, rkeys, expected)
+		}
+		reversed := slices.Clone(expected)
+		slices.Reverse(reversed)
+		rkeys = rangeKeys(t, r, begin, end, document.Descending)
+		if !slices.EqualFunc(rkeys, reversed, bytes.Equal) {
+			t.Errorf("descending [% x, % x): % x != % x", begin, end, rkeys, reversed)
+		}
+	}
+
+	tx = transact(t, store, false)
+	assertRange(t, tx, begin, end, keys)
+	// The begin key is inclusive and the end key is exclusive
+	assertRange(t, tx, keys[2], keys[5], keys[2:5])
+	assertRange(t, tx, keys[3], keys[3], [][]byte{})
+	assertRange(t, tx, keys[5], keys[3], [][]byte{})
+	assertRange(t, tx, keys[4], document.KeyNext(keys[4]), keys[4:5])
+	commit(t, tx)
+
+	// Uncommitted writes are merged into the ranges of the transaction
+	tx = transact(t, store, true)
+	extra := testKey(prefix, []byte("aa"))
+	if err := tx.Set(extra, extra); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Delete(keys[0]); err != nil {
+		t.Fatal(err)
+	}
+	expected := append(slices.Clone(keys[1:]), extra)
+	slices.SortFunc(expected, bytes.Compare)
+	assertRange(t, tx, begin, end, expected)
+	if err := tx.Cancel(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TransactionIsolationTest tests that the transactions read the snapshots taken when they begin, and that
+// the uncommitted writes are invisible to the other transactions.
+func TransactionIsolationTest(t *testing.T, store kv.Store) {
+	t.Helper()
+	prefix := testPrefix(t)
+	k1 := testKey(prefix, []byte("k1"))
+	k2 := testKey(prefix, []byte("k2"))
+
+	tx := transact(t, store, true)
+	if err := tx.Set(k1, []byte("v1")); err != nil {
+		t.Fatal(err)
+	}
+	commit(t, tx)
+
+	reader := transact(t, store, false)
+	writer := transact(t, store, true)
+	if err := writer.Set(k1, []byte("v1'")); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Set(k2, []byte("v2")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Uncommitted writes are invisible
+	other := transact(t, store, false)
+	assertValue(t, other, k1, []byte("v1"))
+	assertValue(t, other, k2, nil)
+	commit(t, other)
+
+	commit(t, writer)
+
+	// Committed writes are invisible to the transactions which began before the commit
+	assertValue(t, reader, k1, []byte("v1"))
+	assertValue(t, reader, k2, nil)
+	if keys := rangeKeys(t, reader, prefix, document.KeyStrinc(prefix), document.Ascending); len(keys) != 1 {
+		t.Errorf("% x", keys)
+	}
+	commit(t, reader)
+
+	// Committed writes are visible to the transactions which begin after the commit
+	after := transact(t, store, false)
+	assertValue(t, after, k1, []byte("v1'"))
+	assertValue(t, after, k2, []byte("v2"))
+	commit(t, after)
+}
+
+// TransactionAtomicCommitTest tests that the writes of the transactions are applied all together on commit,
+// and discarded all together on cancel.
+func TransactionAtomicCommitTest(t *testing.T, store kv.Store) {
+	t.Helper()
+	prefix := testPrefix(t)
+
+	keys := [][]byte{}
+	for n := range 100 {
+		keys = append(keys, testKey(prefix, []byte{byte(n)}))
+	}
+
+	tx := transact(t, store, true)
+	for _, key := range keys {
+		if err := tx.Set(key, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tx.Cancel(); err != nil {
+		t.Fatal(err)
+	}
+
+	tx = transact(t, store, false)
+	if rkeys := rangeKeys(t, tx, prefix, document.KeyStrinc(prefix), document.Ascending); len(rkeys) != 0 {
+		t.Errorf("canceled writes are visible: %d keys", len(rkeys))
+	}
+	commit(t, tx)
+
+	tx = transact(t, store, true)
+	for _, key := range keys {
+		if err := tx.Set(key, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	commit(t, tx)
+
+	tx = transact(t, store, false)
+	if rkeys := rangeKeys(t, tx, prefix, document.KeyStrinc(prefix), document.Ascending); !slices.EqualFunc(rkeys, keys, bytes.Equal) {
+		t.Errorf("committed writes are not visible: %d keys != %d keys", len(rkeys), len(keys))
+	}
+	commit(t, tx)
+
+	// Closed transactions can not be used
+	tx = transact(t, store, true)
+	commit(t, tx)
+	if err := tx.Set(keys[0], keys[0]); err == nil {
+		t.Errorf("write in committed transaction")
+	}
+	if err := tx.Commit(); err == nil {
+		t.Errorf("commit of committed transaction")
+	}
+	tx = transact(t, store, true)
+	if err := tx.Cancel(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.Get(keys[0]); err == nil {
+		t.Errorf("read in canceled transaction")
+	}
+}
+
+// TransactionReadOnlyTest tests that the read-only transactions read the items but refuse to write them.
+func TransactionReadOnlyTest(t *testing.T, store kv.Store) {
+	t.Helper()
+	prefix := testPrefix(t)
+	key := testKey(prefix, []byte("k"))
+
+	tx := transact(t, store, true)
+	if err := tx.Set(key, []byte("v")); err != nil {
+		t.Fatal(err)
+	}
+	commit(t, tx)
+
+	tx = transact(t, store, false)
+	if !tx.IsReadOnly() {
+		t.Errorf("read-only transaction is not read-only")
+	}
+	assertValue(t, tx, key, []byte("v"))
+	if err := tx.Set(key, []byte("v'")); !errors.Is(err, document.ErrNotSupported) {
+		t.Errorf("%v", err)
+	}
+	if err := tx.Delete(key); !errors.Is(err, document.ErrNotSupported) {
+		t.Errorf("%v", err)
+	}
+	commit(t, tx)
+
+	tx = transact(t, store, false)
+	assertValue(t, tx, key, []byte("v"))
+	commit(t, tx)
+}
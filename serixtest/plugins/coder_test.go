@@ -15,10 +15,13 @@
 package plugins
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/cybergarage/go-serix/serix/document"
+	"github.com/cybergarage/go-serix/serix/document/kv"
 	"github.com/cybergarage/go-serix/serix/plugins"
+	"github.com/cybergarage/go-serix/serix/plugins/document/key/fdbtuple"
 	"github.com/cybergarage/go-serix/serixtest"
 )
 
@@ -30,11 +33,46 @@ func TestPlugins(t *testing.T) {
 	t.Run("key", func(t *testing.T) {
 		for _, coder := range mgr.KeyCoders() {
 			t.Run(coder.Name(), func(t *testing.T) {
-				serixtest.KeyCoderSuite(t, coder)
+				switch coder.(type) {
+				case *fdbtuple.Coder:
+					serixtest.FDBTupleKeyCoderSuite(t, coder)
+				default:
+					serixtest.KeyCoderSuite(t, coder)
+				}
 			})
 		}
 	})
 
+	t.Run("document type", func(t *testing.T) {
+		for _, docType := range kv.DocumentTypes() {
+			t.Run(docType.String(), func(t *testing.T) {
+				header := kv.NewKeyHeaderWith(kv.DocumentObject, docType)
+				if header.DocumentType() != docType {
+					t.Errorf("%v != %v", header.DocumentType(), docType)
+				}
+				coder, err := mgr.ObjectCoderForHeader(header)
+				if err != nil {
+					t.Fatal(err)
+				}
+				serixtest.ObjectSerializerSuite(t, coder)
+				coderType, err := mgr.DocumentTypeForObjectCoder(coder)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if coderType != docType {
+					t.Errorf("%v != %v", coderType, docType)
+				}
+			})
+		}
+
+		if _, err := mgr.ObjectCoderForHeader(kv.IndexKeyHeader); !errors.Is(err, document.ErrNotSupported) {
+			t.Errorf("index key header should not be supported: %v", err)
+		}
+		if _, err := mgr.ObjectCoderForDocumentType(kv.DocumentType(0)); !errors.Is(err, document.ErrNotSupported) {
+			t.Errorf("document type 0 should not be supported: %v", err)
+		}
+	})
+
 	t.Run("object", func(t *testing.T) {
 		objSerializer := []document.ObjectCoder{}
 		objCompressor := []document.ObjectCoder{}
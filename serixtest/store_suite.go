@@ -0,0 +1,60 @@
+// Copyright (C) 2025 The go-serix Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serixtest
+
+import (
+	"testing"
+
+	"github.com/cybergarage/go-serix/serix/document/kv"
+	kvtest "github.com/cybergarage/go-serix/serixtest/document/kv"
+)
+
+// StoreSuite tests that the given transactional key-value store honors the ordering, isolation and atomic commit
+// semantics of kv.Store. The tests write the keys beginning with their test names, so the store may have other keys.
+func StoreSuite(t *testing.T, store kv.Store) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		test func(t *testing.T, store kv.Store)
+	}{
+		{
+			name: "TransactionReadWriteTest",
+			test: kvtest.TransactionReadWriteTest,
+		},
+		{
+			name: "TransactionOrderTest",
+			test: kvtest.TransactionOrderTest,
+		},
+		{
+			name: "TransactionIsolationTest",
+			test: kvtest.TransactionIsolationTest,
+		},
+		{
+			name: "TransactionAtomicCommitTest",
+			test: kvtest.TransactionAtomicCommitTest,
+		},
+		{
+			name: "TransactionReadOnlyTest",
+			test: kvtest.TransactionReadOnlyTest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, store)
+		})
+	}
+}